@@ -20,13 +20,17 @@
 //
 // Usage:
 //
-//	myshift <command> [options]
+//	myshift [global options] <command> [options]
 //
 // Available commands:
 //   - next: Show the next upcoming on-call shift for a user
+//   - now: Show who is on call right now across schedules and escalation levels
 //   - plan: Display planned shifts for a schedule over a date range
 //   - override: Create schedule overrides for specific time periods
 //   - upcoming: Show all upcoming shifts for a user
+//   - swap: Trade shifts with another user
+//   - schedules: List schedules and save a default schedule ID
+//   - serve: Publish calendar and JSON feeds over HTTP
 //   - repl: Start an interactive shell for running multiple commands
 //   - config: Manage application configuration
 //
@@ -34,16 +38,29 @@
 //   - Linux: ~/.config/myshift.yaml
 //   - macOS: ~/Library/Application Support/myshift.yaml
 //
+// A configuration file may hold several named profiles, selected with
+// --profile, $MYSHIFT_PROFILE or the file's default_profile. Settings can
+// also be given as MYSHIFT_* environment variables or global flags such as
+// --my-user, which take precedence over the file; no file is needed when
+// the PagerDuty token is given this way.
+//
 // See 'myshift config --print' for sample configuration.
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	_ "time/tzdata" // Time zone names for --tz on systems without a zoneinfo database
 
 	"github.com/jdcasey/myshift-go/internal/commands"
 	"github.com/jdcasey/myshift-go/internal/config"
 	"github.com/jdcasey/myshift-go/internal/pagerduty"
+	"github.com/jdcasey/myshift-go/internal/types"
 )
 
 // version holds the application version and can be set at build time using ldflags:
@@ -51,23 +68,63 @@ import (
 //	go build -ldflags="-X main.version=v1.0.0"
 var version = "dev"
 
+// Exit codes returned by myshift. Scripts can use these to distinguish
+// configuration problems from transient API failures.
+const (
+	exitError        = 1   // General failure
+	exitUnauthorized = 3   // PagerDuty rejected the API token
+	exitForbidden    = 4   // API token lacks the required scope
+	exitNotFound     = 5   // Schedule, user or other object not found
+	exitRateLimited  = 6   // PagerDuty rate limit exceeded
+	exitValidation   = 7   // PagerDuty rejected the request input
+	exitInterrupted  = 130 // Cancelled with Ctrl+C
+)
+
 // main is the entry point for the myshift CLI application.
 func main() {
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		if hint := commands.ErrorHint(err); hint != "" {
+			fmt.Fprintf(os.Stderr, "Hint: %s\n", hint)
+		}
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode maps an error to the process exit status.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case pagerduty.IsUnauthorized(err):
+		return exitUnauthorized
+	case pagerduty.IsForbidden(err):
+		return exitForbidden
+	case pagerduty.IsNotFound(err):
+		return exitNotFound
+	case pagerduty.IsRateLimited(err):
+		return exitRateLimited
+	case pagerduty.IsValidation(err):
+		return exitValidation
+	default:
+		return exitError
 	}
 }
 
 // run contains the main application logic and returns errors instead of calling os.Exit
 func run() error {
-	if len(os.Args) < 2 {
+	opts, args, err := parseGlobalOptions(os.Args[1:])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
 		printUsage()
 		return fmt.Errorf("no command provided")
 	}
 
-	command := os.Args[1]
-	args := os.Args[2:]
+	command := args[0]
+	args = args[1:]
 
 	// Handle special commands that don't require config
 	switch command {
@@ -75,36 +132,75 @@ func run() error {
 		fmt.Printf("myshift-go %s\n", version)
 		return nil
 	case "config":
-		return handleConfigCommand(args)
+		return handleConfigCommand(opts, args)
 	case "--help", "-h", "help":
 		printUsage()
 		return nil
 	}
 
 	// Load configuration for all other commands
-	cfg, err := config.Load()
+	cfg, err := config.LoadWithOptions(opts)
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 
 	// Create command context
-	ctx := commands.NewCommandContext(
+	cmdCtx := commands.NewCommandContext(
 		pagerduty.NewClient(cfg.PagerDutyToken),
 		cfg,
 		os.Stdout,
 	)
 
-	// Handle REPL separately to avoid circular dependency
+	// Handle REPL separately to avoid circular dependency. The REPL installs
+	// its own interrupt handler so that Ctrl+C cancels only the running command.
 	if command == "repl" {
-		replCmd := commands.NewReplCommand(ctx)
-		return replCmd.Execute(args)
+		replCmd := commands.NewReplCommand(cmdCtx)
+		return replCmd.Execute(context.Background(), args)
 	}
 
+	// Cancel in-flight API requests when the user presses Ctrl+C, and stop
+	// serving when the process is terminated
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Create command registry
-	registry := commands.NewCommandRegistry(ctx)
+	registry := commands.NewCommandRegistry(cmdCtx)
 
 	// Execute the command
-	return registry.Execute(command, args)
+	return registry.Execute(ctx, command, args)
+}
+
+// parseGlobalOptions removes the options that come before the command from
+// args, returning the profile chosen with --profile and the settings given as
+// flags, along with the remaining arguments.
+func parseGlobalOptions(args []string) (config.Options, []string, error) {
+	opts := config.Options{Flags: make(map[string]string)}
+	names := map[string]string{"--profile": ""}
+	for _, key := range config.OverrideKeys() {
+		names[config.FlagName(key)] = key
+	}
+
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		name, value, hasValue := strings.Cut(args[0], "=")
+		key, ok := names["--"+strings.TrimLeft(name, "-")]
+		if !ok {
+			break
+		}
+		if !hasValue {
+			if len(args) < 2 {
+				return opts, nil, fmt.Errorf("%s requires a value", name)
+			}
+			value, args = args[1], args[1:]
+		}
+		args = args[1:]
+
+		if key == "" {
+			opts.Profile = value
+		} else {
+			opts.Flags[key] = value
+		}
+	}
+	return opts, args, nil
 }
 
 // printUsage displays the application usage information
@@ -112,35 +208,48 @@ func printUsage() {
 	fmt.Print(`myshift-go - PagerDuty on-call schedule management tool
 
 Usage:
-  myshift-go <command> [options]
+  myshift-go [global options] <command> [options]
 
 Commands:
   next      Show next shift for a user
+  now       Show who is on call right now
   plan      Show planned shifts for a schedule
   override  Create schedule overrides
   upcoming  Show upcoming shifts for a user
+  swap      Trade shifts with another user
+  schedules List schedules and choose a default
+  serve     Publish calendar and JSON feeds over HTTP
   repl      Start interactive REPL
   config    Manage configuration
   --version Show version
 
+Global options:
+  --profile name          Use a profile from the config file (default:
+                          $MYSHIFT_PROFILE, else default_profile)
+  --pagerduty-token token PagerDuty API token
+  --schedule-id id        Default schedule ID
+  --default-schedule name Alias or ID of the default schedule
+  --my-user email         Default user
+  --time-zone zone        Time zone for dates and times
+
+Each setting may also be given as an environment variable, such as
+MYSHIFT_PAGERDUTY_TOKEN or MYSHIFT_MY_USER. Flags take precedence over the
+environment, which takes precedence over the config file. Run 'myshift-go
+config' to see where each setting comes from.
+
 Use 'myshift-go <command> --help' for more information about a command.
 `)
 }
 
 // handleConfigCommand processes the 'config' command and its subcommands
-func handleConfigCommand(args []string) error {
+func handleConfigCommand(opts config.Options, args []string) error {
 	if len(args) == 0 {
-		// Default behavior: load and show basic config info
-		cfg, err := config.Load()
+		// Default behavior: load and show the effective settings
+		cfg, err := config.LoadWithOptions(opts)
 		if err != nil {
 			return fmt.Errorf("loading configuration: %w", err)
 		}
-
-		fmt.Printf("Configuration loaded successfully from one of:\n")
-		for _, path := range config.GetConfigPaths() {
-			fmt.Printf("  %s\n", path)
-		}
-		fmt.Printf("PagerDuty token: %s\n", maskToken(cfg.PagerDutyToken))
+		printSettings(cfg)
 		return nil
 	}
 
@@ -194,44 +303,21 @@ func handleConfigValidation() error {
 	}
 	fmt.Println()
 
-	// Show required fields
-	fmt.Println("Required fields:")
-	for field, present := range result.RequiredFields {
-		if present {
-			fmt.Printf("  ✓ %s: present\n", field)
-		} else {
-			fmt.Printf("  ❌ %s: MISSING\n", field)
-		}
-	}
-	fmt.Println()
-
-	// Show optional fields
-	fmt.Println("Optional fields:")
-	for field, present := range result.OptionalFields {
-		if present {
-			fmt.Printf("  ✓ %s: present\n", field)
-		} else {
-			fmt.Printf("  - %s: not set\n", field)
-		}
-	}
-	fmt.Println()
+	printValidationDetails(result, "")
 
-	// Show errors
-	if len(result.Errors) > 0 {
-		fmt.Println("Errors:")
-		for _, err := range result.Errors {
-			fmt.Printf("  ❌ %s\n", err)
+	// Each profile is reported on its own
+	for _, profileResult := range result.Profiles {
+		status := "✅ VALID"
+		if !profileResult.Valid {
+			status = "❌ INVALID"
 		}
-		fmt.Println()
-	}
-
-	// Show warnings
-	if len(result.Warnings) > 0 {
-		fmt.Println("Warnings:")
-		for _, warning := range result.Warnings {
-			fmt.Printf("  ⚠️  %s\n", warning)
+		if profileResult.Profile == result.DefaultProfile {
+			fmt.Printf("Profile %s (default): %s\n", profileResult.Profile, status)
+		} else {
+			fmt.Printf("Profile %s: %s\n", profileResult.Profile, status)
 		}
 		fmt.Println()
+		printValidationDetails(profileResult, "  ")
 	}
 
 	// Show next steps if needed
@@ -245,7 +331,7 @@ func handleConfigValidation() error {
 		fmt.Println("For a sample configuration:")
 		fmt.Println("  myshift config --print")
 		return fmt.Errorf("configuration is invalid")
-	} else if len(result.Warnings) > 0 {
+	} else if hasWarnings(result) {
 		fmt.Println("Configuration is valid but consider setting the optional fields above for better user experience.")
 	} else {
 		fmt.Println("🎉 Configuration is perfect!")
@@ -254,6 +340,93 @@ func handleConfigValidation() error {
 	return nil
 }
 
+// printSettings shows the file and profile a configuration was loaded from,
+// and each effective setting with where it came from.
+func printSettings(cfg *types.Config) {
+	if path, err := config.FindConfigFile(); err == nil {
+		fmt.Printf("Configuration file: %s\n", path)
+	} else {
+		fmt.Println("Configuration file: none")
+	}
+	if cfg.Profile != "" {
+		fmt.Printf("Profile: %s\n", cfg.Profile)
+	}
+	fmt.Println()
+
+	fmt.Println("Settings:")
+	for _, setting := range config.Settings(cfg) {
+		switch {
+		case setting.Value == "":
+			fmt.Printf("  %-23s (not set)\n", setting.Key)
+		case setting.Key == "pagerduty_token":
+			fmt.Printf("  %-23s %s (from %s)\n", setting.Key, maskToken(setting.Value), setting.Source)
+		default:
+			fmt.Printf("  %-23s %s (from %s)\n", setting.Key, setting.Value, setting.Source)
+		}
+	}
+}
+
+// hasWarnings reports whether result or any of its profiles has warnings.
+func hasWarnings(result *config.ValidationResult) bool {
+	if len(result.Warnings) > 0 {
+		return true
+	}
+	for _, profileResult := range result.Profiles {
+		if len(profileResult.Warnings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// printValidationDetails shows the fields, errors and warnings of a
+// validation result, indented by indent.
+func printValidationDetails(result *config.ValidationResult, indent string) {
+	// Show required fields
+	if len(result.RequiredFields) > 0 {
+		fmt.Printf("%sRequired fields:\n", indent)
+		for field, present := range result.RequiredFields {
+			if present {
+				fmt.Printf("%s  ✓ %s: present\n", indent, field)
+			} else {
+				fmt.Printf("%s  ❌ %s: MISSING\n", indent, field)
+			}
+		}
+		fmt.Println()
+	}
+
+	// Show optional fields
+	if len(result.OptionalFields) > 0 {
+		fmt.Printf("%sOptional fields:\n", indent)
+		for field, present := range result.OptionalFields {
+			if present {
+				fmt.Printf("%s  ✓ %s: present\n", indent, field)
+			} else {
+				fmt.Printf("%s  - %s: not set\n", indent, field)
+			}
+		}
+		fmt.Println()
+	}
+
+	// Show errors
+	if len(result.Errors) > 0 {
+		fmt.Printf("%sErrors:\n", indent)
+		for _, err := range result.Errors {
+			fmt.Printf("%s  ❌ %s\n", indent, err)
+		}
+		fmt.Println()
+	}
+
+	// Show warnings
+	if len(result.Warnings) > 0 {
+		fmt.Printf("%sWarnings:\n", indent)
+		for _, warning := range result.Warnings {
+			fmt.Printf("%s  ⚠️  %s\n", indent, warning)
+		}
+		fmt.Println()
+	}
+}
+
 // maskToken masks a PagerDuty token for display
 func maskToken(token string) string {
 	if len(token) <= 8 {
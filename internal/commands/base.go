@@ -15,10 +15,12 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/jdcasey/myshift-go/internal/pagerduty"
@@ -30,6 +32,7 @@ type BaseCommand struct {
 	client pagerduty.PagerDutyClient
 	config *types.Config
 	writer io.Writer
+	reader io.Reader
 }
 
 // NewBaseCommand creates a new base command with shared dependencies
@@ -41,70 +44,178 @@ func NewBaseCommand(client pagerduty.PagerDutyClient, config *types.Config, writ
 		client: client,
 		config: config,
 		writer: writer,
+		reader: os.Stdin,
 	}
 }
 
 // ResolveUser resolves a user email, falling back to config if empty
-func (b *BaseCommand) ResolveUser(email string) (*types.User, error) {
+func (b *BaseCommand) ResolveUser(ctx context.Context, email string) (*types.User, error) {
 	if email == "" {
 		email = b.config.MyUser
 	}
 	if email == "" {
 		return nil, fmt.Errorf("user email is required (use --user flag or set my_user in config)")
 	}
-	return b.client.FindUserByEmail(email)
+	return b.client.FindUserByEmail(ctx, email)
 }
 
-// GetScheduleID returns the schedule ID, ensuring it's configured
-func (b *BaseCommand) GetScheduleID() (string, error) {
-	if b.config.ScheduleID == "" {
-		return "", fmt.Errorf("schedule_id must be configured")
+// ResolveScheduleID resolves a schedule alias or ID to a PagerDuty schedule ID.
+// Aliases are looked up in the config's schedules map; anything else is treated
+// as a literal schedule ID. An empty selector resolves to the default schedule.
+func (b *BaseCommand) ResolveScheduleID(selector string) (string, error) {
+	if selector == "" {
+		return b.defaultScheduleID()
 	}
-	return b.config.ScheduleID, nil
+	if id, ok := b.config.Schedules[selector]; ok {
+		return id, nil
+	}
+	return selector, nil
+}
+
+// ResolveScheduleIDs resolves several schedule aliases or IDs, dropping duplicates.
+// An empty list resolves to the default schedule.
+func (b *BaseCommand) ResolveScheduleIDs(selectors []string) ([]string, error) {
+	if len(selectors) == 0 {
+		id, err := b.defaultScheduleID()
+		if err != nil {
+			return nil, err
+		}
+		return []string{id}, nil
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, selector := range selectors {
+		id, err := b.ResolveScheduleID(selector)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// defaultScheduleID returns the configured default schedule. It prefers
+// default_schedule, then schedule_id, then the only entry in schedules.
+func (b *BaseCommand) defaultScheduleID() (string, error) {
+	for _, selector := range []string{b.config.DefaultSchedule, b.config.ScheduleID} {
+		if selector == "" {
+			continue
+		}
+		if id, ok := b.config.Schedules[selector]; ok {
+			return id, nil
+		}
+		return selector, nil
+	}
+
+	if len(b.config.Schedules) == 1 {
+		for _, id := range b.config.Schedules {
+			return id, nil
+		}
+	}
+
+	return "", fmt.Errorf("no default schedule configured (use --schedule, or set default_schedule or schedule_id in config)")
 }
 
-// BuildTimeRangeParams builds URL parameters for time range queries
+// Location returns the time zone for parsing and showing times. It uses tz
+// (the --tz flag) if set, then time_zone from the config, then the local zone.
+// The name "schedule" selects the time zone of scheduleID, or of the default
+// schedule if scheduleID is empty, falling back to the local zone if there is
+// no such schedule or it has no time zone.
+func (b *BaseCommand) Location(ctx context.Context, tz, scheduleID string) (*time.Location, error) {
+	if tz == "" {
+		tz = b.config.TimeZone
+	}
+	if !strings.EqualFold(tz, ScheduleTimeZone) {
+		return LoadTimeZone(tz)
+	}
+
+	if scheduleID == "" {
+		id, err := b.defaultScheduleID()
+		if err != nil {
+			return LoadTimeZone("")
+		}
+		scheduleID = id
+	}
+	schedule, err := b.client.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching time zone of schedule %s: %w", scheduleID, err)
+	}
+	if schedule.TimeZone == "" {
+		return LoadTimeZone("")
+	}
+	return LoadTimeZone(schedule.TimeZone)
+}
+
+// FormatOptions returns the formatter settings chosen by the flags, with times
+// shown in loc. A --template value naming a template in the config resolves to
+// that template's text; anything else is used as the template text itself.
+func (b *BaseCommand) FormatOptions(flags *CommonFlags, loc *time.Location) FormatOptions {
+	options := FormatOptions{
+		Columns:  flags.Columns,
+		Template: flags.Template,
+		Location: loc,
+		Alarms:   flags.Alarms,
+	}
+	if text, ok := b.config.Templates[flags.Template]; ok {
+		options.Template = text
+	}
+	return options
+}
+
+// BuildTimeRangeParams builds URL parameters for time range queries. Full user
+// and schedule objects are included so shifts carry each user's email and each
+// schedule's name and time zone, which references alone do not.
 func (b *BaseCommand) BuildTimeRangeParams(start, end time.Time) url.Values {
 	return url.Values{
-		"since":    []string{start.Format(time.RFC3339)},
-		"until":    []string{end.Format(time.RFC3339)},
-		"overflow": []string{"true"},
+		"since":     []string{start.Format(time.RFC3339)},
+		"until":     []string{end.Format(time.RFC3339)},
+		"overflow":  []string{"true"},
+		"include[]": []string{"users", "schedules"},
 	}
 }
 
-// GetOnCallsForUser fetches on-call shifts for a specific user
-func (b *BaseCommand) GetOnCallsForUser(scheduleID string, userID string, start, end time.Time) ([]types.OnCall, error) {
+// GetOnCallsForUser fetches on-call shifts for a specific user across one or more schedules
+func (b *BaseCommand) GetOnCallsForUser(ctx context.Context, scheduleIDs []string, userID string, start, end time.Time) ([]types.OnCall, error) {
 	params := b.BuildTimeRangeParams(start, end)
 	params.Add("user_ids[]", userID)
-	params.Add("schedule_ids[]", scheduleID)
+	for _, scheduleID := range scheduleIDs {
+		params.Add("schedule_ids[]", scheduleID)
+	}
 
-	onCalls, err := b.client.GetOnCalls(params)
+	onCalls, err := b.client.GetOnCalls(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching shifts: %w", err)
 	}
 
-	return DeduplicateOnCalls(onCalls), nil
+	return SortOnCalls(DeduplicateOnCalls(onCalls)), nil
 }
 
-// GetOnCallsForSchedule fetches all on-call shifts for a schedule
-func (b *BaseCommand) GetOnCallsForSchedule(scheduleID string, start, end time.Time) ([]types.OnCall, error) {
+// GetOnCallsForSchedules fetches all on-call shifts for one or more schedules,
+// merged and sorted by start time
+func (b *BaseCommand) GetOnCallsForSchedules(ctx context.Context, scheduleIDs []string, start, end time.Time) ([]types.OnCall, error) {
 	params := b.BuildTimeRangeParams(start, end)
-	params.Add("schedule_ids[]", scheduleID)
+	for _, scheduleID := range scheduleIDs {
+		params.Add("schedule_ids[]", scheduleID)
+	}
 
-	onCalls, err := b.client.GetOnCalls(params)
+	onCalls, err := b.client.GetOnCalls(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching shifts: %w", err)
 	}
 
-	return DeduplicateOnCalls(onCalls), nil
+	return SortOnCalls(DeduplicateOnCalls(onCalls)), nil
 }
 
 // BuildUserMap creates a map of user IDs to names from on-call shifts
-func (b *BaseCommand) BuildUserMap(onCalls []types.OnCall) map[string]string {
+func (b *BaseCommand) BuildUserMap(ctx context.Context, onCalls []types.OnCall) map[string]string {
 	userMap := make(map[string]string)
 	for _, shift := range onCalls {
 		if _, exists := userMap[shift.User.ID]; !exists {
-			user, err := b.client.GetUser(shift.User.ID)
+			user, err := b.client.GetUser(ctx, shift.User.ID)
 			if err != nil {
 				// If we can't get user details, use what we have
 				userMap[shift.User.ID] = shift.User.Name
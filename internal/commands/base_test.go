@@ -0,0 +1,132 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+func TestBaseCommand_ResolveScheduleIDs(t *testing.T) {
+	aliases := map[string]string{
+		"primary": "PPRIMARY",
+		"db":      "PDB",
+	}
+
+	tests := []struct {
+		testName  string
+		config    types.Config
+		selectors []string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			testName: "legacy schedule_id",
+			config:   types.Config{ScheduleID: "SCHED123"},
+			want:     []string{"SCHED123"},
+		},
+		{
+			testName: "default_schedule alias",
+			config:   types.Config{Schedules: aliases, DefaultSchedule: "db"},
+			want:     []string{"PDB"},
+		},
+		{
+			testName: "default_schedule wins over schedule_id",
+			config:   types.Config{Schedules: aliases, DefaultSchedule: "primary", ScheduleID: "SCHED123"},
+			want:     []string{"PPRIMARY"},
+		},
+		{
+			testName: "single alias is the implicit default",
+			config:   types.Config{Schedules: map[string]string{"only": "PONLY"}},
+			want:     []string{"PONLY"},
+		},
+		{
+			testName: "several aliases without default",
+			config:   types.Config{Schedules: aliases},
+			wantErr:  true,
+		},
+		{
+			testName:  "aliases and raw IDs",
+			config:    types.Config{Schedules: aliases},
+			selectors: []string{"primary", "PRAW", "db", "PPRIMARY"},
+			want:      []string{"PPRIMARY", "PRAW", "PDB"},
+		},
+		{
+			testName: "nothing configured",
+			config:   types.Config{},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			config := tt.config
+			base := NewBaseCommand(NewMockPagerDutyClient(), &config, nil)
+
+			got, err := base.ResolveScheduleIDs(tt.selectors)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveScheduleIDs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveScheduleIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseCommand_Location(t *testing.T) {
+	t.Setenv("TZ", "Europe/Berlin")
+
+	tests := []struct {
+		testName   string
+		tz         string
+		configTZ   string
+		scheduleID string
+		want       string
+		wantErr    bool
+	}{
+		{testName: "local by default", want: "Europe/Berlin"},
+		{testName: "config", configTZ: "Asia/Kolkata", want: "Asia/Kolkata"},
+		{testName: "flag wins over config", tz: "America/Chicago", configTZ: "Asia/Kolkata", want: "America/Chicago"},
+		{testName: "explicit local", tz: "local", configTZ: "Asia/Kolkata", want: "Europe/Berlin"},
+		{testName: "schedule", tz: "schedule", scheduleID: "PSYD", want: "Australia/Sydney"},
+		{testName: "default schedule", configTZ: "schedule", want: "America/Los_Angeles"},
+		{testName: "schedule without time zone", tz: "schedule", scheduleID: "PNOTZ", want: "Europe/Berlin"},
+		{testName: "unknown schedule", tz: "schedule", scheduleID: "PMISSING", wantErr: true},
+		{testName: "unknown zone", tz: "Mars/Olympus_Mons", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			client := NewMockPagerDutyClient()
+			client.AddSchedule("PSYD", "Sydney", "Australia/Sydney")
+			client.AddSchedule("PSFO", "San Francisco", "America/Los_Angeles")
+			client.AddSchedule("PNOTZ", "No zone", "")
+			config := types.Config{ScheduleID: "PSFO", TimeZone: tt.configTZ}
+			base := NewBaseCommand(client, &config, nil)
+
+			got, err := base.Location(context.Background(), tt.tz, tt.scheduleID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Location() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("Location() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"github.com/jdcasey/myshift-go/internal/pagerduty"
+)
+
+// ErrorHint returns an actionable suggestion for a command error, or an empty
+// string if the error has no specific remedy. It is used by the CLI and the
+// REPL to explain PagerDuty API failures in terms of what the user can fix.
+func ErrorHint(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case pagerduty.IsUnauthorized(err):
+		return "your PagerDuty token was rejected; check pagerduty_token in your configuration"
+	case pagerduty.IsForbidden(err):
+		return "your PagerDuty token lacks the required scope; creating overrides needs a token with write access"
+	case pagerduty.IsRateLimited(err):
+		return "PagerDuty rate limit exceeded; wait a minute and try again"
+	case pagerduty.IsNotFound(err):
+		return "check that the schedule ID and user emails are correct"
+	case pagerduty.IsValidation(err):
+		return "PagerDuty rejected the request input; check the times and users you provided"
+	default:
+		return ""
+	}
+}
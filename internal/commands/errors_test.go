@@ -0,0 +1,51 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jdcasey/myshift-go/internal/pagerduty"
+)
+
+func TestErrorHint(t *testing.T) {
+	tests := []struct {
+		testName string
+		err      error
+		want     string
+	}{
+		{"nil", nil, ""},
+		{"plain error", errors.New("boom"), ""},
+		{"unauthorized", &pagerduty.APIError{StatusCode: 401}, "token was rejected"},
+		{"forbidden wrapped", fmt.Errorf("error creating overrides: %w", &pagerduty.APIError{StatusCode: 403}), "write access"},
+		{"rate limited", &pagerduty.APIError{StatusCode: 429}, "rate limit"},
+		{"not found", &pagerduty.APIError{StatusCode: 404}, "schedule ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			got := ErrorHint(tt.err)
+			if tt.want == "" && got != "" {
+				t.Errorf("Expected no hint, got %q", got)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("Expected hint to contain %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
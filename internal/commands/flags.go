@@ -17,15 +17,41 @@ package commands
 import (
 	"flag"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // CommonFlags holds common command-line flags used across commands
 type CommonFlags struct {
-	User   string
-	Days   int
-	Format string
-	Start  string
-	End    string
+	User      string
+	Days      int
+	Format    string
+	Start     string
+	End       string
+	Schedules []string
+	Columns   []string
+	Template  string
+	TimeZone  string
+	Alarms    []string
+	OnlyUser  string
+}
+
+// stringListFlag collects repeated (or comma-separated) occurrences of a flag
+type stringListFlag []string
+
+// String implements flag.Value
+func (s *stringListFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+// Set implements flag.Value
+func (s *stringListFlag) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*s = append(*s, part)
+		}
+	}
+	return nil
 }
 
 // FlagParser provides a unified way to parse command flags
@@ -76,6 +102,42 @@ func (p *FlagParser) AddEndFlag(defaultValue, usage string) *FlagParser {
 	return p
 }
 
+// AddScheduleFlag adds the repeatable --schedule flag
+func (p *FlagParser) AddScheduleFlag(usage string) *FlagParser {
+	p.fs.Var((*stringListFlag)(&p.flags.Schedules), "schedule", usage)
+	return p
+}
+
+// AddColumnsFlag adds the --columns flag for csv and tsv output
+func (p *FlagParser) AddColumnsFlag(usage string) *FlagParser {
+	p.fs.Var((*stringListFlag)(&p.flags.Columns), "columns", usage)
+	return p
+}
+
+// AddTemplateFlag adds the --template flag for template output
+func (p *FlagParser) AddTemplateFlag(usage string) *FlagParser {
+	p.fs.StringVar(&p.flags.Template, "template", "", usage)
+	return p
+}
+
+// AddAlarmFlag adds the repeatable --alarm flag for ical output
+func (p *FlagParser) AddAlarmFlag(usage string) *FlagParser {
+	p.fs.Var((*stringListFlag)(&p.flags.Alarms), "alarm", usage)
+	return p
+}
+
+// AddOnlyUserFlag adds the --only-user flag
+func (p *FlagParser) AddOnlyUserFlag(usage string) *FlagParser {
+	p.fs.StringVar(&p.flags.OnlyUser, "only-user", "", usage)
+	return p
+}
+
+// AddTimeZoneFlag adds the --tz flag
+func (p *FlagParser) AddTimeZoneFlag(usage string) *FlagParser {
+	p.fs.StringVar(&p.flags.TimeZone, "tz", "", usage)
+	return p
+}
+
 // SetUsage sets the usage function for the flag set
 func (p *FlagParser) SetUsage(usage func()) *FlagParser {
 	p.fs.Usage = usage
@@ -118,10 +180,15 @@ func (p *FlagParser) ValidateRequired(required RequiredFlags) error {
 
 // OverrideFlags holds flags specific to the override command
 type OverrideFlags struct {
-	User   string
-	Target string
-	Start  string
-	End    string
+	User        string
+	Target      string
+	Start       string
+	End         string
+	Schedule    string
+	WholeShifts bool
+	DryRun      bool
+	Yes         bool
+	TimeZone    string
 }
 
 // ParseOverrideFlags parses flags for the override command
@@ -131,8 +198,13 @@ func ParseOverrideFlags(args []string) (*OverrideFlags, error) {
 
 	fs.StringVar(&flags.User, "user", "", "User email to override with (required)")
 	fs.StringVar(&flags.Target, "target", "", "Target user email to override (required)")
-	fs.StringVar(&flags.Start, "start", "", "Start time (YYYY-MM-DD HH:MM) (required)")
-	fs.StringVar(&flags.End, "end", "", "End time (YYYY-MM-DD HH:MM) (required)")
+	fs.StringVar(&flags.Start, "start", "", "Start time, e.g. '2025-01-15 09:00' or 'tomorrow 09:00' (required)")
+	fs.StringVar(&flags.End, "end", "", "End time, e.g. '2025-01-15 17:00' or '+8h' after the start (required)")
+	fs.StringVar(&flags.Schedule, "schedule", "", "Schedule alias or ID (uses the default schedule if not provided)")
+	fs.StringVar(&flags.TimeZone, "tz", "", "Time zone for dates and times: an IANA name, local, or schedule (default: time_zone from config, else local)")
+	fs.BoolVar(&flags.WholeShifts, "whole-shifts", false, "Override each affected shift in full instead of only the requested window")
+	fs.BoolVar(&flags.DryRun, "dry-run", false, "Show the overrides that would be created without creating them")
+	fs.BoolVar(&flags.Yes, "yes", false, "Create the overrides without asking for confirmation")
 
 	fs.Usage = func() {
 		fmt.Print(`Usage: myshift override [options]
@@ -140,8 +212,13 @@ func ParseOverrideFlags(args []string) (*OverrideFlags, error) {
 Options:
   --user string     User email to override with (required)
   --target string   Target user email to override (required)  
-  --start string    Start time (YYYY-MM-DD HH:MM) (required)
-  --end string      End time (YYYY-MM-DD HH:MM) (required)
+  --start string    Start time, e.g. '2025-01-15 09:00' or 'tomorrow 09:00' (required)
+  --end string      End time, e.g. '2025-01-15 17:00' or '+8h' after the start (required)
+  --schedule string Schedule alias or ID (uses the default schedule if not provided)
+  --tz string       Time zone for dates and times: an IANA name, local, or schedule
+  --whole-shifts    Override each affected shift in full instead of only the requested window
+  --dry-run         Show the overrides that would be created without creating them
+  --yes             Create the overrides without asking for confirmation
 
 `)
 	}
@@ -161,3 +238,325 @@ Options:
 
 	return flags, nil
 }
+
+// NowFlags holds flags specific to the now command
+type NowFlags struct {
+	Schedules          []string
+	EscalationPolicies []string
+	Format             string
+	TimeZone           string
+}
+
+// ParseNowFlags parses flags for the now command
+func ParseNowFlags(args []string) (*NowFlags, error) {
+	fs := flag.NewFlagSet("now", flag.ContinueOnError)
+	flags := &NowFlags{}
+
+	fs.Var((*stringListFlag)(&flags.Schedules), "schedule", "Only show these schedules (alias or ID); repeatable")
+	fs.Var((*stringListFlag)(&flags.EscalationPolicies), "escalation-policy", "Only show these escalation policy IDs; repeatable")
+	fs.StringVar(&flags.TimeZone, "tz", "", "Time zone for shift end times: an IANA name, local, or schedule (default: time_zone from config, else local)")
+	fs.StringVar(&flags.Format, "format", "text", "Output format (text, json)")
+	fs.StringVar(&flags.Format, "o", "text", "Output format (text, json) (short)")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: myshift now [options]
+
+Options:
+  --schedule string           Only show these schedules (alias or ID); repeatable
+  --escalation-policy string  Only show these escalation policy IDs; repeatable
+  --tz string                 Time zone for shift end times: an IANA name, local, or schedule
+  --format, -o string         Output format: text, json (default: text)
+
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		// Handle help request gracefully - don't treat it as an error
+		if err == flag.ErrHelp {
+			return nil, nil // Return nil flags and nil error for help
+		}
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+// ServeFlags holds flags specific to the serve command
+type ServeFlags struct {
+	Listen   string
+	TTL      time.Duration
+	Days     int
+	TimeZone string
+	Alarms   []string
+}
+
+// ParseServeFlags parses flags for the serve command
+func ParseServeFlags(args []string) (*ServeFlags, error) {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags := &ServeFlags{}
+
+	fs.StringVar(&flags.Listen, "listen", ":8080", "Address to listen on")
+	fs.DurationVar(&flags.TTL, "ttl", 5*time.Minute, "How long feeds are cached before they are refreshed")
+	fs.IntVar(&flags.Days, "days", 90, "Number of days of shifts in calendar feeds")
+	fs.StringVar(&flags.TimeZone, "tz", "", "Time zone for now.json: an IANA name, local, or schedule (default: time_zone from config, else local)")
+	fs.Var((*stringListFlag)(&flags.Alarms), "alarm", "Add a reminder this long before each shift in calendar feeds, e.g. 15m or 1d; repeatable")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: myshift serve [options]
+
+Options:
+  --listen string   Address to listen on (default: :8080)
+  --ttl duration    How long feeds are cached before they are refreshed (default: 5m)
+  --days int        Number of days of shifts in calendar feeds (default: 90)
+  --tz string       Time zone for now.json: an IANA name, local, or schedule
+  --alarm string    Add a reminder this long before each shift in calendar feeds; repeatable
+
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		// Handle help request gracefully - don't treat it as an error
+		if err == flag.ErrHelp {
+			return nil, nil // Return nil flags and nil error for help
+		}
+		return nil, err
+	}
+
+	if flags.TTL <= 0 {
+		return nil, fmt.Errorf("--ttl must be positive")
+	}
+	if flags.Days <= 0 {
+		return nil, fmt.Errorf("--days must be positive")
+	}
+
+	return flags, nil
+}
+
+// SwapFlags holds flags specific to the swap command
+type SwapFlags struct {
+	User     string
+	With     string
+	Mine     string
+	Theirs   string
+	Schedule string
+	DryRun   bool
+	Yes      bool
+	TimeZone string
+}
+
+// ParseSwapFlags parses flags for the swap command
+func ParseSwapFlags(args []string) (*SwapFlags, error) {
+	fs := flag.NewFlagSet("swap", flag.ContinueOnError)
+	flags := &SwapFlags{}
+
+	fs.StringVar(&flags.User, "user", "", "Your email (uses my_user from config if not provided)")
+	fs.StringVar(&flags.With, "with", "", "Email of the user to swap with (required)")
+	fs.StringVar(&flags.Mine, "mine", "", "Date of your shift to hand over, e.g. 2025-03-04 or 'next tuesday' (required)")
+	fs.StringVar(&flags.Theirs, "theirs", "", "Date of their shift to take, e.g. 2025-03-08 or +2w (required)")
+	fs.StringVar(&flags.Schedule, "schedule", "", "Schedule alias or ID (uses the default schedule if not provided)")
+	fs.StringVar(&flags.TimeZone, "tz", "", "Time zone for dates and times: an IANA name, local, or schedule (default: time_zone from config, else local)")
+	fs.BoolVar(&flags.DryRun, "dry-run", false, "Show the overrides that would be created without creating them")
+	fs.BoolVar(&flags.Yes, "yes", false, "Create the overrides without asking for confirmation")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: myshift swap --with email --mine date --theirs date [options]
+
+Options:
+  --user string     Your email (uses my_user from config if not provided)
+  --with string     Email of the user to swap with (required)
+  --mine string     Date of your shift to hand over, e.g. 2025-03-04 or 'next tuesday' (required)
+  --theirs string   Date of their shift to take, e.g. 2025-03-08 or +2w (required)
+  --schedule string Schedule alias or ID (uses the default schedule if not provided)
+  --tz string       Time zone for dates and times: an IANA name, local, or schedule
+  --dry-run         Show the overrides that would be created without creating them
+  --yes             Create the overrides without asking for confirmation
+
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		// Handle help request gracefully - don't treat it as an error
+		if err == flag.ErrHelp {
+			return nil, nil // Return nil flags and nil error for help
+		}
+		return nil, err
+	}
+
+	// Validate required flags
+	if flags.With == "" || flags.Mine == "" || flags.Theirs == "" {
+		return nil, fmt.Errorf("--with, --mine, and --theirs are all required")
+	}
+
+	return flags, nil
+}
+
+// SchedulesFlags holds flags specific to the schedules command
+type SchedulesFlags struct {
+	Query  string
+	Format string
+}
+
+// ParseSchedulesFlags parses flags for the schedules command
+func ParseSchedulesFlags(args []string) (*SchedulesFlags, error) {
+	fs := flag.NewFlagSet("schedules", flag.ContinueOnError)
+	flags := &SchedulesFlags{}
+
+	fs.StringVar(&flags.Query, "query", "", "Only show schedules whose name matches this text")
+	fs.StringVar(&flags.Format, "format", "text", "Output format (text, json)")
+	fs.StringVar(&flags.Format, "o", "text", "Output format (text, json) (short)")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: myshift schedules [options]
+
+Options:
+  --query string       Only show schedules whose name matches this text
+  --format, -o string  Output format: text, json (default: text)
+
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		// Handle help request gracefully - don't treat it as an error
+		if err == flag.ErrHelp {
+			return nil, nil // Return nil flags and nil error for help
+		}
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments, returning the positional arguments in order.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// OverrideListFlags holds flags specific to the override list subcommand
+type OverrideListFlags struct {
+	User     string
+	Since    string
+	Until    string
+	Schedule string
+	TimeZone string
+}
+
+// ParseOverrideListFlags parses flags for the override list subcommand
+func ParseOverrideListFlags(args []string) (*OverrideListFlags, error) {
+	fs := flag.NewFlagSet("override list", flag.ContinueOnError)
+	flags := &OverrideListFlags{}
+
+	fs.StringVar(&flags.User, "user", "", "Only show overrides assigned to this user email")
+	fs.StringVar(&flags.Since, "since", "", "Start of the range, e.g. 2025-01-15 or today (default: now)")
+	fs.StringVar(&flags.Until, "until", "", "End of the range, e.g. 2025-02-01 or 'end of month' (default: 28 days after since)")
+	fs.StringVar(&flags.Schedule, "schedule", "", "Schedule alias or ID (uses the default schedule if not provided)")
+	fs.StringVar(&flags.TimeZone, "tz", "", "Time zone for dates and times: an IANA name, local, or schedule (default: time_zone from config, else local)")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: myshift override list [options]
+
+Options:
+  --user string     Only show overrides assigned to this user email
+  --since string    Start of the range, e.g. 2025-01-15 or today (default: now)
+  --until string    End of the range, e.g. 2025-02-01 or 'end of month' (default: 28 days after since)
+  --schedule string Schedule alias or ID (uses the default schedule if not provided)
+  --tz string       Time zone for dates and times: an IANA name, local, or schedule
+
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		// Handle help request gracefully - don't treat it as an error
+		if err == flag.ErrHelp {
+			return nil, nil // Return nil flags and nil error for help
+		}
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+// OverrideDeleteFlags holds flags specific to the override delete subcommand
+type OverrideDeleteFlags struct {
+	IDs      []string
+	Mine     bool
+	Range    bool
+	User     string
+	Since    string
+	Until    string
+	Schedule string
+	Yes      bool
+	TimeZone string
+}
+
+// ParseOverrideDeleteFlags parses flags for the override delete subcommand
+func ParseOverrideDeleteFlags(args []string) (*OverrideDeleteFlags, error) {
+	fs := flag.NewFlagSet("override delete", flag.ContinueOnError)
+	flags := &OverrideDeleteFlags{}
+
+	fs.BoolVar(&flags.Mine, "mine", false, "Delete overrides assigned to you in the range")
+	fs.BoolVar(&flags.Range, "range", false, "Delete all overrides in the range given by --since and --until")
+	fs.StringVar(&flags.User, "user", "", "User email for --mine (uses my_user from config if not provided)")
+	fs.StringVar(&flags.Since, "since", "", "Start of the range, e.g. 2025-01-15 or today (default: now)")
+	fs.StringVar(&flags.Until, "until", "", "End of the range, e.g. 2025-02-01 or 'end of month' (default: 28 days after since)")
+	fs.StringVar(&flags.Schedule, "schedule", "", "Schedule alias or ID (uses the default schedule if not provided)")
+	fs.StringVar(&flags.TimeZone, "tz", "", "Time zone for dates and times: an IANA name, local, or schedule (default: time_zone from config, else local)")
+	fs.BoolVar(&flags.Yes, "yes", false, "Delete without asking for confirmation")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: myshift override delete <id>... | --mine | --range [options]
+
+Options:
+  --mine            Delete overrides assigned to you in the range
+  --range           Delete all overrides in the range given by --since and --until
+  --user string     User email for --mine (uses my_user from config if not provided)
+  --since string    Start of the range, e.g. 2025-01-15 or today (default: now)
+  --until string    End of the range, e.g. 2025-02-01 or 'end of month' (default: 28 days after since)
+  --schedule string Schedule alias or ID (uses the default schedule if not provided)
+  --tz string       Time zone for dates and times: an IANA name, local, or schedule
+  --yes             Delete without asking for confirmation
+
+`)
+	}
+
+	ids, err := parseInterspersed(fs, args)
+	if err != nil {
+		// Handle help request gracefully - don't treat it as an error
+		if err == flag.ErrHelp {
+			return nil, nil // Return nil flags and nil error for help
+		}
+		return nil, err
+	}
+	flags.IDs = ids
+
+	// Validate that exactly one selection mode is used
+	modes := 0
+	if len(flags.IDs) > 0 {
+		modes++
+	}
+	if flags.Mine {
+		modes++
+	}
+	if flags.Range {
+		modes++
+	}
+	if modes != 1 {
+		return nil, fmt.Errorf("specify exactly one of: override IDs, --mine, or --range")
+	}
+	if flags.Range && (flags.Since == "" || flags.Until == "") {
+		return nil, fmt.Errorf("--range requires both --since and --until")
+	}
+
+	return flags, nil
+}
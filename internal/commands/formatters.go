@@ -16,8 +16,11 @@
 package commands
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -51,8 +54,14 @@ func (f *TextFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap
 		return err
 	}
 
+	// Label each row with its schedule when shifts from several schedules are merged
+	showSchedule := spansMultipleSchedules(shifts)
+
 	for _, shift := range shifts {
 		userName := userMap[shift.User.ID]
+		if showSchedule {
+			userName = fmt.Sprintf("%s [%s]", userName, shift.Schedule.Name)
+		}
 		_, err := fmt.Fprintf(writer, "%s to %s: %s\n",
 			shift.Start.Format("2006-01-02 15:04 MST"),
 			shift.End.Format("2006-01-02 15:04 MST"),
@@ -66,127 +75,274 @@ func (f *TextFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap
 	return nil
 }
 
-// ICalFormatter formats plan output as iCalendar (.ics) format.
-type ICalFormatter struct{}
+// JSONSchemaVersion is the version of the JSON output schema. It changes only
+// when a field is removed, renamed or changes meaning; fields may be added
+// without a new version, so consumers should ignore fields they do not know.
+const JSONSchemaVersion = 1
 
-// NewICalFormatter creates a new iCal formatter.
-func NewICalFormatter() *ICalFormatter {
-	return &ICalFormatter{}
+// JSONDocument is the top-level object written by JSONFormatter:
+//
+//	{
+//	  "schema_version": 1,
+//	  "start": "2025-01-01T00:00:00Z",   // requested range
+//	  "end": "2025-01-29T00:00:00Z",
+//	  "shifts": [ ... ]                  // JSONShift objects, in start order
+//	}
+type JSONDocument struct {
+	SchemaVersion int         `json:"schema_version"`
+	Start         time.Time   `json:"start"`
+	End           time.Time   `json:"end"`
+	Shifts        []JSONShift `json:"shifts"`
 }
 
-// Format outputs the shifts in iCalendar format.
-func (f *ICalFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap map[string]string, start, end time.Time) error {
-	// Write iCal header
-	_, err := fmt.Fprintf(writer, "BEGIN:VCALENDAR\r\n")
-	if err != nil {
-		return err
+// JSONShift is one shift in a JSONDocument. Times are RFC 3339.
+//
+// Start, end and duration_seconds are null for users who are permanently on
+// call. Schedule is null when an escalation rule targets the user directly.
+// The escalation fields are only present in output of the "now" command.
+type JSONShift struct {
+	Start            *time.Time     `json:"start"`
+	End              *time.Time     `json:"end"`
+	DurationSeconds  *int64         `json:"duration_seconds"`
+	User             JSONUser       `json:"user"`
+	Schedule         *JSONReference `json:"schedule"`
+	EscalationPolicy *JSONReference `json:"escalation_policy,omitempty"`
+	EscalationLevel  int            `json:"escalation_level,omitempty"`
+}
+
+// JSONUser identifies the user on call for a JSONShift.
+type JSONUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// JSONReference identifies a schedule or escalation policy in a JSONShift.
+type JSONReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// JSONFormatter formats plan output as a versioned JSON document.
+type JSONFormatter struct{}
+
+// NewJSONFormatter creates a new JSON formatter.
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{}
+}
+
+// Format outputs the shifts as a JSONDocument.
+func (f *JSONFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap map[string]string, start, end time.Time) error {
+	document := JSONDocument{
+		SchemaVersion: JSONSchemaVersion,
+		Start:         start,
+		End:           end,
+		Shifts:        make([]JSONShift, 0, len(shifts)),
 	}
 
-	_, err = fmt.Fprintf(writer, "VERSION:2.0\r\n")
-	if err != nil {
-		return err
+	for _, shift := range shifts {
+		document.Shifts = append(document.Shifts, newJSONShift(shift, userMap))
 	}
 
-	_, err = fmt.Fprintf(writer, "PRODID:-//myshift-go//ON-CALL SCHEDULE//EN\r\n")
-	if err != nil {
-		return err
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
+
+// shiftUserName returns the name of the user on call for shift, preferring
+// the name in userMap over the one embedded in the shift.
+func shiftUserName(shift types.OnCall, userMap map[string]string) string {
+	if name := userMap[shift.User.ID]; name != "" {
+		return name
 	}
+	return shift.User.Name
+}
 
-	_, err = fmt.Fprintf(writer, "CALSCALE:GREGORIAN\r\n")
-	if err != nil {
-		return err
+// newJSONShift converts a shift to its JSON form, preferring names from userMap.
+func newJSONShift(shift types.OnCall, userMap map[string]string) JSONShift {
+	entry := JSONShift{
+		User: JSONUser{
+			ID:    shift.User.ID,
+			Name:  shiftUserName(shift, userMap),
+			Email: shift.User.Email,
+		},
 	}
 
-	// Write events for each shift
-	for i, shift := range shifts {
-		userName := userMap[shift.User.ID]
+	if !shift.Start.IsZero() && !shift.End.IsZero() {
+		start, end := shift.Start, shift.End
+		duration := int64(end.Sub(start) / time.Second)
+		entry.Start, entry.End, entry.DurationSeconds = &start, &end, &duration
+	}
+	if shift.Schedule.ID != "" {
+		entry.Schedule = &JSONReference{ID: shift.Schedule.ID, Name: shift.Schedule.Name}
+	}
+	if shift.EscalationPolicy.ID != "" {
+		entry.EscalationPolicy = &JSONReference{ID: shift.EscalationPolicy.ID, Name: shift.EscalationPolicy.Summary}
+		entry.EscalationLevel = shift.EscalationLevel
+	}
 
-		// Generate a unique UID for each event
-		uid := fmt.Sprintf("oncall-%d-%s@myshift-go", i, shift.User.ID)
+	return entry
+}
 
-		_, err = fmt.Fprintf(writer, "BEGIN:VEVENT\r\n")
-		if err != nil {
-			return err
-		}
+// DefaultColumns are the columns written by the CSV and TSV formatters when
+// none are chosen.
+var DefaultColumns = []string{"start", "end", "user", "email", "schedule", "hours"}
+
+// DelimitedFormatter formats plan output as CSV or TSV for spreadsheets.
+// Fields are quoted per RFC 4180 where needed. Times are written in a single,
+// explicit location that is named in the header, in a form LibreOffice and
+// Excel recognize as dates.
+type DelimitedFormatter struct {
+	comma    rune
+	columns  []string
+	location *time.Location
+}
 
-		_, err = fmt.Fprintf(writer, "UID:%s\r\n", uid)
-		if err != nil {
-			return err
-		}
+// NewCSVFormatter creates a comma-separated formatter with the given columns
+// (DefaultColumns if empty), writing times in loc.
+func NewCSVFormatter(columns []string, loc *time.Location) (*DelimitedFormatter, error) {
+	return newDelimitedFormatter(',', columns, loc)
+}
 
-		_, err = fmt.Fprintf(writer, "DTSTART:%s\r\n", shift.Start.UTC().Format("20060102T150405Z"))
-		if err != nil {
-			return err
-		}
+// NewTSVFormatter creates a tab-separated formatter with the given columns
+// (DefaultColumns if empty), writing times in loc.
+func NewTSVFormatter(columns []string, loc *time.Location) (*DelimitedFormatter, error) {
+	return newDelimitedFormatter('\t', columns, loc)
+}
 
-		_, err = fmt.Fprintf(writer, "DTEND:%s\r\n", shift.End.UTC().Format("20060102T150405Z"))
-		if err != nil {
-			return err
+// newDelimitedFormatter validates the columns and creates a DelimitedFormatter.
+func newDelimitedFormatter(comma rune, columns []string, loc *time.Location) (*DelimitedFormatter, error) {
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	for _, column := range columns {
+		if !isDelimitedColumn(column) {
+			return nil, fmt.Errorf("unknown column: %s (supported: %s)", column, strings.Join(DefaultColumns, ", "))
 		}
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
 
-		_, err = fmt.Fprintf(writer, "SUMMARY:On-Call: %s\r\n", f.escapeICalText(userName))
-		if err != nil {
-			return err
-		}
+	return &DelimitedFormatter{
+		comma:    comma,
+		columns:  columns,
+		location: loc,
+	}, nil
+}
 
-		_, err = fmt.Fprintf(writer, "DESCRIPTION:On-call shift for %s\\nSchedule: %s\r\n",
-			f.escapeICalText(userName), f.escapeICalText(shift.Schedule.Name))
-		if err != nil {
-			return err
+// isDelimitedColumn reports whether column is one of DefaultColumns.
+func isDelimitedColumn(column string) bool {
+	for _, known := range DefaultColumns {
+		if column == known {
+			return true
 		}
+	}
+	return false
+}
 
-		_, err = fmt.Fprintf(writer, "CATEGORIES:ON-CALL\r\n")
-		if err != nil {
-			return err
+// Format outputs a header row followed by one row per shift.
+func (f *DelimitedFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap map[string]string, start, end time.Time) error {
+	w := csv.NewWriter(writer)
+	w.Comma = f.comma
+	w.UseCRLF = f.comma == ','
+
+	header := make([]string, 0, len(f.columns))
+	for _, column := range f.columns {
+		if column == "start" || column == "end" {
+			header = append(header, fmt.Sprintf("%s (%s)", column, f.location))
+			continue
 		}
+		header = append(header, column)
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
 
-		_, err = fmt.Fprintf(writer, "STATUS:CONFIRMED\r\n")
-		if err != nil {
-			return err
+	for _, shift := range shifts {
+		record := make([]string, 0, len(f.columns))
+		for _, column := range f.columns {
+			record = append(record, f.value(column, shift, userMap))
 		}
-
-		_, err = fmt.Fprintf(writer, "TRANSP:OPAQUE\r\n")
-		if err != nil {
+		if err := w.Write(record); err != nil {
 			return err
 		}
+	}
 
-		// Add timestamp
-		_, err = fmt.Fprintf(writer, "DTSTAMP:%s\r\n", time.Now().UTC().Format("20060102T150405Z"))
-		if err != nil {
-			return err
-		}
+	w.Flush()
+	return w.Error()
+}
 
-		_, err = fmt.Fprintf(writer, "END:VEVENT\r\n")
-		if err != nil {
-			return err
-		}
+// value returns the field for column in the row of shift.
+func (f *DelimitedFormatter) value(column string, shift types.OnCall, userMap map[string]string) string {
+	switch column {
+	case "start":
+		return shift.Start.In(f.location).Format("2006-01-02 15:04:05")
+	case "end":
+		return shift.End.In(f.location).Format("2006-01-02 15:04:05")
+	case "user":
+		return shiftUserName(shift, userMap)
+	case "email":
+		return shift.User.Email
+	case "schedule":
+		return shift.Schedule.Name
+	case "hours":
+		return fmt.Sprintf("%.2f", shift.End.Sub(shift.Start).Hours())
+	default:
+		return ""
 	}
-
-	// Write iCal footer
-	_, err = fmt.Fprintf(writer, "END:VCALENDAR\r\n")
-	return err
 }
 
-// escapeICalText escapes special characters in iCalendar text values.
-func (f *ICalFormatter) escapeICalText(text string) string {
-	// Escape special characters according to RFC 5545
-	text = strings.ReplaceAll(text, "\\", "\\\\")
-	text = strings.ReplaceAll(text, ",", "\\,")
-	text = strings.ReplaceAll(text, ";", "\\;")
-	text = strings.ReplaceAll(text, "\n", "\\n")
-	text = strings.ReplaceAll(text, "\r", "\\r")
-	return text
+// FormatOptions holds settings that apply to some formatters only.
+type FormatOptions struct {
+	Columns  []string       // Columns for csv and tsv output
+	Template string         // Template text for template output
+	Location *time.Location // Time zone for csv and tsv output (default: UTC)
+	Alarms   []string       // Reminders before each shift for ical output, e.g. 1h or 15m
+	Calendar string         // Calendar name for ical output
 }
 
 // GetFormatter returns the appropriate formatter based on the format string.
-func GetFormatter(format string) (PlanFormatter, error) {
-	switch strings.ToLower(format) {
+func GetFormatter(format string, options FormatOptions) (PlanFormatter, error) {
+	format = strings.ToLower(format)
+	if len(options.Columns) > 0 && format != "csv" && format != "tsv" {
+		return nil, fmt.Errorf("--columns is only supported with csv and tsv formats")
+	}
+	if options.Template != "" && format != "template" {
+		return nil, fmt.Errorf("--template is only supported with the template format")
+	}
+	if len(options.Alarms) > 0 && format != "ical" && format != "ics" {
+		return nil, fmt.Errorf("--alarm is only supported with the ical format")
+	}
+
+	switch format {
 	case "text", "txt":
 		return NewTextFormatter(), nil
 	case "ical", "ics":
-		return NewICalFormatter(), nil
+		alarms, err := parseAlarms(options.Alarms)
+		if err != nil {
+			return nil, err
+		}
+		return NewICalFormatter(alarms, options.Calendar), nil
+	case "json":
+		return NewJSONFormatter(), nil
+	case "csv":
+		return NewCSVFormatter(options.Columns, options.Location)
+	case "tsv":
+		return NewTSVFormatter(options.Columns, options.Location)
+	case "markdown", "md":
+		return NewMarkdownFormatter(), nil
+	case "html":
+		return NewHTMLFormatter(), nil
+	case "calendar", "cal":
+		return NewCalendarFormatter(), nil
+	case "template":
+		if options.Template == "" {
+			return nil, fmt.Errorf("--template is required with the template format")
+		}
+		return NewTemplateFormatter(options.Template)
 	default:
-		return nil, fmt.Errorf("unsupported format: %s (supported: text, ical)", format)
+		return nil, fmt.Errorf("unsupported format: %s (supported: text, ical, json, csv, tsv, markdown, html, template, calendar)", format)
 	}
 }
 
@@ -217,3 +373,25 @@ func DeduplicateOnCalls(onCalls []types.OnCall) []types.OnCall {
 
 	return uniqueShifts
 }
+
+// SortOnCalls sorts on-call shifts by start time, then by schedule name, so
+// that shifts merged from several schedules are listed chronologically.
+func SortOnCalls(onCalls []types.OnCall) []types.OnCall {
+	sort.SliceStable(onCalls, func(i, j int) bool {
+		if !onCalls[i].Start.Equal(onCalls[j].Start) {
+			return onCalls[i].Start.Before(onCalls[j].Start)
+		}
+		return onCalls[i].Schedule.Name < onCalls[j].Schedule.Name
+	})
+	return onCalls
+}
+
+// spansMultipleSchedules reports whether the shifts come from more than one schedule.
+func spansMultipleSchedules(shifts []types.OnCall) bool {
+	for _, shift := range shifts {
+		if shift.Schedule.ID != shifts[0].Schedule.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// minCalendarCellWidth is the narrowest day cell, in columns, excluding
+// borders. Narrower terminals get a grid wider than the screen rather than
+// an unreadable one.
+const minCalendarCellWidth = 6
+
+// minHandoffTimeCellWidth is the narrowest day cell that shows handoff times;
+// narrower cells show only the handoff mark so the name stays readable.
+const minHandoffTimeCellWidth = 14
+
+// calendarColors are the ANSI foreground colors assigned to users in turn.
+var calendarColors = []int{31, 32, 33, 34, 35, 36, 91, 92, 93, 94, 95, 96}
+
+// calendarBorders holds the characters used to draw the calendar grid.
+type calendarBorders struct {
+	horizontal, vertical                  string
+	topLeft, topMiddle, topRight          string
+	middleLeft, middleMiddle, middleRight string
+	bottomLeft, bottomMiddle, bottomRight string
+	handoff, ellipsis                     string
+}
+
+var unicodeBorders = calendarBorders{
+	horizontal: "─", vertical: "│",
+	topLeft: "┌", topMiddle: "┬", topRight: "┐",
+	middleLeft: "├", middleMiddle: "┼", middleRight: "┤",
+	bottomLeft: "└", bottomMiddle: "┴", bottomRight: "┘",
+	handoff: "↳", ellipsis: "…",
+}
+
+var asciiBorders = calendarBorders{
+	horizontal: "-", vertical: "|",
+	topLeft: "+", topMiddle: "+", topRight: "+",
+	middleLeft: "+", middleMiddle: "+", middleRight: "+",
+	bottomLeft: "+", bottomMiddle: "+", bottomRight: "+",
+	handoff: ">", ellipsis: "~",
+}
+
+// calendarLine is one line of a day cell: a user on call that day.
+type calendarLine struct {
+	text   string
+	userID string
+}
+
+// CalendarFormatter formats plan output as a week-by-week calendar grid, with
+// each day listing who is on call. A shift that starts during a day, rather
+// than at midnight, is marked with its handoff time.
+//
+// Users are color-coded when writing to a terminal. Setting NO_COLOR turns off
+// colors and draws the grid in plain ASCII. The grid is sized to the terminal
+// width, or $COLUMNS if set.
+type CalendarFormatter struct {
+	noColor    bool
+	isTerminal func(io.Writer) bool
+	width      func(io.Writer) int
+}
+
+// NewCalendarFormatter creates a new calendar formatter.
+func NewCalendarFormatter() *CalendarFormatter {
+	return &CalendarFormatter{
+		noColor:    os.Getenv("NO_COLOR") != "",
+		isTerminal: isTerminalWriter,
+		width:      terminalWidth,
+	}
+}
+
+// Format outputs the shifts as a calendar grid covering start to end.
+func (f *CalendarFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap map[string]string, start, end time.Time) error {
+	borders := unicodeBorders
+	if f.noColor {
+		borders = asciiBorders
+	}
+	useColor := !f.noColor && f.isTerminal(writer)
+
+	cellWidth := (f.width(writer)-1)/7 - 3
+	if cellWidth < minCalendarCellWidth {
+		cellWidth = minCalendarCellWidth
+	}
+	headerLayout := "Mon 02"
+	if cellWidth >= len("Mon 01-02") {
+		headerLayout = "Mon 01-02"
+	}
+
+	colors := make(map[string]int)
+	for _, shift := range shifts {
+		if _, ok := colors[shift.User.ID]; !ok {
+			colors[shift.User.ID] = calendarColors[len(colors)%len(calendarColors)]
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "On call from %s to %s\n", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	b.WriteString(f.border(borders, borders.topLeft, borders.topMiddle, borders.topRight, cellWidth))
+
+	firstDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for week := weekStart(start); week.Before(end); week = week.AddDate(0, 0, 7) {
+		if !week.Equal(weekStart(start)) {
+			b.WriteString(f.border(borders, borders.middleLeft, borders.middleMiddle, borders.middleRight, cellWidth))
+		}
+
+		var days [7][]calendarLine
+		var headers [7]calendarLine
+		rows := 1
+		for i := range days {
+			day := week.AddDate(0, 0, i)
+			headers[i] = calendarLine{text: day.Format(headerLayout)}
+			if day.Before(firstDay) || !day.Before(end) {
+				continue
+			}
+			days[i] = calendarDayLines(day, shifts, userMap, borders, cellWidth >= minHandoffTimeCellWidth)
+			if len(days[i]) > rows {
+				rows = len(days[i])
+			}
+		}
+
+		b.WriteString(f.row(borders, headers[:], cellWidth, nil))
+		b.WriteString(f.border(borders, borders.middleLeft, borders.middleMiddle, borders.middleRight, cellWidth))
+		for row := 0; row < rows; row++ {
+			var lines [7]calendarLine
+			for i := range days {
+				if row < len(days[i]) {
+					lines[i] = days[i][row]
+				}
+			}
+			var lineColors map[string]int
+			if useColor {
+				lineColors = colors
+			}
+			b.WriteString(f.row(borders, lines[:], cellWidth, lineColors))
+		}
+	}
+
+	b.WriteString(f.border(borders, borders.bottomLeft, borders.bottomMiddle, borders.bottomRight, cellWidth))
+
+	_, err := io.WriteString(writer, b.String())
+	return err
+}
+
+// calendarDayLines lists who is on call during the day starting at day, in
+// start order. Shifts that begin after midnight are marked as handoffs, with
+// the handoff time if showTimes is set.
+func calendarDayLines(day time.Time, shifts []types.OnCall, userMap map[string]string, borders calendarBorders, showTimes bool) []calendarLine {
+	dayEnd := day.AddDate(0, 0, 1)
+
+	var lines []calendarLine
+	for _, shift := range shifts {
+		if !shift.Start.Before(dayEnd) || !shift.End.After(day) {
+			continue
+		}
+		text := shiftUserName(shift, userMap)
+		switch {
+		case shift.Start.After(day) && showTimes:
+			text = fmt.Sprintf("%s%s %s", borders.handoff, shift.Start.In(day.Location()).Format("15:04"), text)
+		case shift.Start.After(day):
+			text = borders.handoff + text
+		}
+		lines = append(lines, calendarLine{text: text, userID: shift.User.ID})
+	}
+	return lines
+}
+
+// border draws a horizontal line of the grid.
+func (f *CalendarFormatter) border(borders calendarBorders, left, middle, right string, cellWidth int) string {
+	segment := strings.Repeat(borders.horizontal, cellWidth+2)
+	segments := make([]string, 7)
+	for i := range segments {
+		segments[i] = segment
+	}
+	return left + strings.Join(segments, middle) + right + "\n"
+}
+
+// row draws one line of text across the seven day cells, truncating text that
+// does not fit and coloring it by user when colors is not nil.
+func (f *CalendarFormatter) row(borders calendarBorders, lines []calendarLine, cellWidth int, colors map[string]int) string {
+	var b strings.Builder
+	b.WriteString(borders.vertical)
+	for _, line := range lines {
+		text := line.text
+		if utf8.RuneCountInString(text) > cellWidth {
+			text = string([]rune(text)[:cellWidth-1]) + borders.ellipsis
+		}
+		padding := strings.Repeat(" ", cellWidth-utf8.RuneCountInString(text))
+
+		if color, ok := colors[line.userID]; ok && line.userID != "" {
+			text = fmt.Sprintf("\x1b[%dm%s\x1b[0m", color, text)
+		}
+		fmt.Fprintf(&b, " %s%s %s", text, padding, borders.vertical)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
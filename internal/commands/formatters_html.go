@@ -0,0 +1,154 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// htmlTemplate is a self-contained page: styles and the sorting script are
+// inline so the file can be opened directly or attached to a wiki page.
+var htmlTemplate = template.Must(template.New("plan").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>On-call schedule: {{.Start}} to {{.End}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.4em 0.8em; text-align: left; }
+th { background: #f0f0f0; cursor: pointer; user-select: none; }
+th[aria-sort="ascending"]::after { content: " \25B2"; }
+th[aria-sort="descending"]::after { content: " \25BC"; }
+.legend { list-style: none; padding: 0; }
+.legend li { display: inline-block; margin: 0 0.5em 0.5em 0; padding: 0.2em 0.6em; }
+{{range .Users}}.{{.Class}} { background: {{.Color}}; }
+{{end}}</style>
+</head>
+<body>
+<h1>On-call schedule</h1>
+<p>{{.Start}} to {{.End}}</p>
+{{if .Rows}}<ul class="legend">
+{{range .Users}}<li class="{{.Class}}">{{.Name}}</li>
+{{end}}</ul>
+<table id="shifts">
+<thead><tr><th>Start</th><th>End</th><th>User</th><th>Schedule</th><th>Hours</th></tr></thead>
+<tbody>
+{{range .Rows}}<tr class="{{.Class}}"><td data-sort="{{.StartSort}}">{{.Start}}</td><td data-sort="{{.EndSort}}">{{.End}}</td><td data-sort="{{.User}}">{{.User}}</td><td data-sort="{{.Schedule}}">{{.Schedule}}</td><td data-sort="{{.Hours}}">{{.Hours}}</td></tr>
+{{end}}</tbody>
+</table>
+{{else}}<p>No shifts found.</p>
+{{end}}<script>
+document.querySelectorAll("#shifts th").forEach(function (th, column) {
+  th.addEventListener("click", function () {
+    var tbody = document.querySelector("#shifts tbody");
+    var ascending = th.getAttribute("aria-sort") !== "ascending";
+    document.querySelectorAll("#shifts th").forEach(function (other) { other.removeAttribute("aria-sort"); });
+    th.setAttribute("aria-sort", ascending ? "ascending" : "descending");
+    var rows = Array.prototype.slice.call(tbody.rows);
+    rows.sort(function (a, b) {
+      var x = a.cells[column].dataset.sort, y = b.cells[column].dataset.sort;
+      var result = isNaN(x) || isNaN(y) ? x.localeCompare(y) : x - y;
+      return ascending ? result : -result;
+    });
+    rows.forEach(function (row) { tbody.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+// htmlPage holds the values rendered by htmlTemplate.
+type htmlPage struct {
+	Start string
+	End   string
+	Users []htmlUser
+	Rows  []htmlRow
+}
+
+// htmlUser is a user's entry in the legend, with the color of their rows.
+type htmlUser struct {
+	Name  string
+	Class string
+	Color template.CSS
+}
+
+// htmlRow is one shift in the table. The *Sort fields hold sortable values.
+type htmlRow struct {
+	Class     string
+	Start     string
+	StartSort string
+	End       string
+	EndSort   string
+	User      string
+	Schedule  string
+	Hours     string
+}
+
+// HTMLFormatter formats plan output as a standalone HTML page with a table
+// that sorts by any column and a distinct color for each user.
+type HTMLFormatter struct{}
+
+// NewHTMLFormatter creates a new HTML formatter.
+func NewHTMLFormatter() *HTMLFormatter {
+	return &HTMLFormatter{}
+}
+
+// Format outputs the shifts as an HTML page.
+func (f *HTMLFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap map[string]string, start, end time.Time) error {
+	page := htmlPage{
+		Start: start.Format("2006-01-02"),
+		End:   end.Format("2006-01-02"),
+	}
+
+	classes := make(map[string]string)
+	for _, shift := range shifts {
+		class, seen := classes[shift.User.ID]
+		if !seen {
+			class = fmt.Sprintf("user-%d", len(page.Users))
+			classes[shift.User.ID] = class
+			page.Users = append(page.Users, htmlUser{
+				Name:  shiftUserName(shift, userMap),
+				Class: class,
+				Color: userColor(len(page.Users)),
+			})
+		}
+
+		page.Rows = append(page.Rows, htmlRow{
+			Class:     class,
+			Start:     shift.Start.Format("2006-01-02 15:04 MST"),
+			StartSort: shift.Start.UTC().Format(time.RFC3339),
+			End:       shift.End.Format("2006-01-02 15:04 MST"),
+			EndSort:   shift.End.UTC().Format(time.RFC3339),
+			User:      shiftUserName(shift, userMap),
+			Schedule:  shift.Schedule.Name,
+			Hours:     fmt.Sprintf("%.2f", shift.End.Sub(shift.Start).Hours()),
+		})
+	}
+
+	return htmlTemplate.Execute(writer, page)
+}
+
+// userColor returns a light background color for the nth user. Hues are
+// spread by the golden angle so neighbouring users stay easy to tell apart.
+func userColor(n int) template.CSS {
+	return template.CSS(fmt.Sprintf("hsl(%d, 65%%, 85%%)", (n*137)%360))
+}
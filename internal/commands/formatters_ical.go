@@ -0,0 +1,361 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// icalLineLimit is the longest line, in octets and excluding the CRLF, that
+// RFC 5545 allows before it must be folded.
+const icalLineLimit = 75
+
+// icalSequenceEpoch is the time from which SEQUENCE numbers are counted.
+var icalSequenceEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ICalFormatter formats plan output as iCalendar (.ics) format.
+//
+// Each event's UID is derived from its schedule, user and start time, so
+// importing an updated feed replaces events instead of duplicating them.
+// LAST-MODIFIED is when the shift last changed, and SEQUENCE counts minutes
+// from icalSequenceEpoch to then, so it only ever increases and a shift whose
+// end moved is updated in place. Without a record of when shifts changed, as
+// for a one-off export, the generation time is used for both. Events are
+// written in their schedule's time zone, with a VTIMEZONE for each zone used.
+type ICalFormatter struct {
+	alarms []time.Duration // Reminders this long before each shift starts
+	name   string          // Calendar name shown by calendar apps; omitted if empty
+	now    func() time.Time
+
+	// changed returns when a shift last changed, or the zero time if that is
+	// not known; nil means the generation time is used for every shift
+	changed func(shift types.OnCall) time.Time
+}
+
+// NewICalFormatter creates a new iCal formatter that adds a reminder for each
+// of alarms before a shift starts and names the calendar name.
+func NewICalFormatter(alarms []time.Duration, name string) *ICalFormatter {
+	return &ICalFormatter{
+		alarms: alarms,
+		name:   name,
+		now:    time.Now,
+	}
+}
+
+// parseAlarms parses --alarm values such as 1h, 15m, 1h30m or 1d into how long
+// before a shift each reminder fires.
+func parseAlarms(values []string) ([]time.Duration, error) {
+	alarms := make([]time.Duration, 0, len(values))
+	for _, value := range values {
+		alarm, err := parseAlarm(value)
+		if err != nil {
+			return nil, err
+		}
+		alarms = append(alarms, alarm)
+	}
+	return alarms, nil
+}
+
+// parseAlarm parses one --alarm value: a Go duration, or a whole number of
+// days (d) or weeks (w).
+func parseAlarm(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if count, ok := strings.CutSuffix(value, suffix); ok {
+			if n, err := strconv.Atoi(count); err == nil && n >= 0 {
+				return time.Duration(n) * unit, nil
+			}
+		}
+	}
+
+	alarm, err := time.ParseDuration(value)
+	if err != nil || alarm < 0 {
+		return 0, fmt.Errorf("invalid --alarm %q: expected a time before the shift such as 15m, 1h, 1h30m or 1d", value)
+	}
+	return alarm, nil
+}
+
+// icalWriter writes folded iCalendar content lines, keeping the first error.
+type icalWriter struct {
+	w   io.Writer
+	err error
+}
+
+// line writes one content line, folded as RFC 5545 requires.
+func (w *icalWriter) line(format string, args ...any) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = io.WriteString(w.w, foldICalLine(fmt.Sprintf(format, args...)))
+}
+
+// foldICalLine splits a content line into lines of at most icalLineLimit
+// octets, each continuation starting with a space, and terminates it with
+// CRLF. Multi-octet UTF-8 characters are never split.
+func foldICalLine(line string) string {
+	var b strings.Builder
+	limit := icalLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space counts towards the limit of continuation lines
+		limit = icalLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// Format outputs the shifts in iCalendar format.
+func (f *ICalFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap map[string]string, start, end time.Time) error {
+	w := &icalWriter{w: writer}
+	generated := f.now().UTC()
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//myshift-go//ON-CALL SCHEDULE//EN")
+	w.line("CALSCALE:GREGORIAN")
+	if f.name != "" {
+		w.line("X-WR-CALNAME:%s", f.escapeICalText(f.name))
+	}
+
+	f.writeTimeZones(w, shifts)
+
+	for _, shift := range shifts {
+		f.writeEvent(w, shift, userMap, generated)
+	}
+
+	w.line("END:VCALENDAR")
+	return w.err
+}
+
+// writeEvent writes one shift as a VEVENT.
+func (f *ICalFormatter) writeEvent(w *icalWriter, shift types.OnCall, userMap map[string]string, generated time.Time) {
+	userName := shiftUserName(shift, userMap)
+	modified := generated
+	if f.changed != nil {
+		if changed := f.changed(shift); !changed.IsZero() {
+			modified = changed.UTC()
+		}
+	}
+
+	w.line("BEGIN:VEVENT")
+	w.line("UID:%s", icalUID(shift))
+	w.line("DTSTAMP:%s", generated.Format("20060102T150405Z"))
+	w.line("LAST-MODIFIED:%s", modified.Format("20060102T150405Z"))
+	w.line("SEQUENCE:%d", int64(modified.Sub(icalSequenceEpoch)/time.Minute))
+	w.line("DTSTART%s", icalDateTime(shift.Start, icalLocation(shift)))
+	w.line("DTEND%s", icalDateTime(shift.End, icalLocation(shift)))
+	w.line("SUMMARY:On-Call: %s", f.escapeICalText(userName))
+	w.line("DESCRIPTION:On-call shift for %s\\nSchedule: %s",
+		f.escapeICalText(userName), f.escapeICalText(shift.Schedule.Name))
+	w.line("CATEGORIES:ON-CALL")
+	w.line("STATUS:CONFIRMED")
+	w.line("TRANSP:OPAQUE")
+	for _, alarm := range f.alarms {
+		w.line("BEGIN:VALARM")
+		w.line("ACTION:DISPLAY")
+		w.line("TRIGGER:-%s", icalDuration(alarm))
+		w.line("DESCRIPTION:On-call shift for %s starts in %s", f.escapeICalText(userName), formatDuration(alarm))
+		w.line("END:VALARM")
+	}
+	w.line("END:VEVENT")
+}
+
+// icalDuration formats d as an RFC 5545 duration, e.g. PT15M, PT1H30M or P1D.
+func icalDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+
+	var b strings.Builder
+	b.WriteString("P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if d > 0 || days == 0 {
+		b.WriteString("T")
+		hours, minutes, seconds := d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second
+		if hours > 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes > 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+		if seconds > 0 || d == 0 {
+			fmt.Fprintf(&b, "%dS", seconds)
+		}
+	}
+	return b.String()
+}
+
+// icalUID returns a UID that stays the same for a shift across exports: the
+// schedule, the user and the start time identify it.
+func icalUID(shift types.OnCall) string {
+	scheduleID := shift.Schedule.ID
+	if scheduleID == "" {
+		scheduleID = "direct"
+	}
+	return fmt.Sprintf("oncall-%s-%s-%s@myshift-go", scheduleID, shift.User.ID, shift.Start.UTC().Format("20060102T150405Z"))
+}
+
+// icalLocation returns the time zone to write a shift in: its schedule's zone
+// if known, otherwise the zone its times are already in. It returns nil when
+// the shift should be written in UTC.
+func icalLocation(shift types.OnCall) *time.Location {
+	loc := shift.Start.Location()
+	if shift.Schedule.TimeZone != "" {
+		if scheduleLoc, err := time.LoadLocation(shift.Schedule.TimeZone); err == nil {
+			loc = scheduleLoc
+		}
+	}
+	// Go's "Local" is not a zone name calendar apps understand
+	if loc == time.UTC || loc == time.Local {
+		return nil
+	}
+	// Neither is a VTIMEZONE needed for zones that are always UTC, like Etc/UTC
+	t := shift.Start.In(loc)
+	if onset, next := t.ZoneBounds(); onset.IsZero() && next.IsZero() {
+		if _, offset := t.Zone(); offset == 0 {
+			return nil
+		}
+	}
+	return loc
+}
+
+// icalDateTime formats t as the value of a DTSTART or DTEND property,
+// including the separator: ":20250115T140000Z" in UTC, or
+// ";TZID=America/New_York:20250115T090000" in loc.
+func icalDateTime(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		return ":" + t.UTC().Format("20060102T150405Z")
+	}
+	return fmt.Sprintf(";TZID=%s:%s", loc, t.In(loc).Format("20060102T150405"))
+}
+
+// writeTimeZones writes a VTIMEZONE for each time zone the shifts are written
+// in, covering the observances from the earliest start to the latest end.
+func (f *ICalFormatter) writeTimeZones(w *icalWriter, shifts []types.OnCall) {
+	type span struct {
+		loc        *time.Location
+		start, end time.Time
+	}
+	spans := make(map[string]*span)
+	for _, shift := range shifts {
+		loc := icalLocation(shift)
+		if loc == nil {
+			continue
+		}
+		s, ok := spans[loc.String()]
+		if !ok {
+			spans[loc.String()] = &span{loc: loc, start: shift.Start, end: shift.End}
+			continue
+		}
+		if shift.Start.Before(s.start) {
+			s.start = shift.Start
+		}
+		if shift.End.After(s.end) {
+			s.end = shift.End
+		}
+	}
+
+	names := make([]string, 0, len(spans))
+	for name := range spans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := spans[name]
+		writeVTimezone(w, s.loc, s.start, s.end)
+	}
+}
+
+// writeVTimezone writes a VTIMEZONE with one STANDARD or DAYLIGHT observance
+// for each period of loc between start and end, beginning with the one in
+// effect at start.
+func writeVTimezone(w *icalWriter, loc *time.Location, start, end time.Time) {
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:%s", loc)
+
+	t := start.In(loc)
+	for {
+		onset, next := t.ZoneBounds()
+		name, offsetTo := t.Zone()
+		offsetFrom := offsetTo
+		dtstart := "19700101T000000"
+		if !onset.IsZero() {
+			_, offsetFrom = onset.Add(-time.Second).Zone()
+			// Observances start at the local time before the change
+			dtstart = onset.In(time.FixedZone("", offsetFrom)).Format("20060102T150405")
+		}
+
+		component := "STANDARD"
+		if t.IsDST() {
+			component = "DAYLIGHT"
+		}
+		w.line("BEGIN:%s", component)
+		w.line("DTSTART:%s", dtstart)
+		w.line("TZOFFSETFROM:%s", formatUTCOffset(offsetFrom))
+		w.line("TZOFFSETTO:%s", formatUTCOffset(offsetTo))
+		w.line("TZNAME:%s", name)
+		w.line("END:%s", component)
+
+		if next.IsZero() || !next.Before(end) {
+			break
+		}
+		t = next.In(loc)
+	}
+
+	w.line("END:VTIMEZONE")
+}
+
+// formatUTCOffset formats an offset in seconds east of UTC as RFC 5545
+// requires, e.g. -0500 or +0530.
+func formatUTCOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	offset := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		offset += fmt.Sprintf("%02d", seconds%60)
+	}
+	return offset
+}
+
+// escapeICalText escapes special characters in iCalendar text values.
+func (f *ICalFormatter) escapeICalText(text string) string {
+	// Escape special characters according to RFC 5545
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	text = strings.ReplaceAll(text, ",", "\\,")
+	text = strings.ReplaceAll(text, ";", "\\;")
+	text = strings.ReplaceAll(text, "\n", "\\n")
+	text = strings.ReplaceAll(text, "\r", "\\r")
+	return text
+}
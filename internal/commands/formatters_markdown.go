@@ -0,0 +1,83 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// MarkdownFormatter formats plan output as GitHub-flavored Markdown, with one
+// table per week so a rotation can be pasted into a README or wiki page.
+type MarkdownFormatter struct{}
+
+// NewMarkdownFormatter creates a new Markdown formatter.
+func NewMarkdownFormatter() *MarkdownFormatter {
+	return &MarkdownFormatter{}
+}
+
+// Format outputs the shifts as Markdown tables grouped by the week (starting
+// Monday) in which each shift starts.
+func (f *MarkdownFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap map[string]string, start, end time.Time) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## On-call schedule: %s to %s\n", start.Format("2006-01-02"), end.Format("2006-01-02"))
+
+	if len(shifts) == 0 {
+		b.WriteString("\nNo shifts found.\n")
+	}
+
+	var week time.Time
+	for i, shift := range shifts {
+		if shiftWeek := weekStart(shift.Start); i == 0 || !shiftWeek.Equal(week) {
+			week = shiftWeek
+			fmt.Fprintf(&b, "\n### Week of %s\n\n", week.Format("2006-01-02"))
+			b.WriteString("| Start | End | User | Schedule |\n")
+			b.WriteString("|-------|-----|------|----------|\n")
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+			shift.Start.Format("2006-01-02 15:04 MST"),
+			shift.End.Format("2006-01-02 15:04 MST"),
+			escapeMarkdownCell(shiftUserName(shift, userMap)),
+			escapeMarkdownCell(shift.Schedule.Name))
+	}
+
+	_, err := io.WriteString(writer, b.String())
+	return err
+}
+
+// weekStart returns midnight on the Monday of the week containing t, in t's location.
+func weekStart(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	year, month, day := t.Date()
+	return time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, t.Location())
+}
+
+// escapeMarkdownCell keeps text from breaking out of a table cell or being
+// rendered as inline HTML. Ampersands are escaped first, so that text that
+// looks like an entity, such as "&lt;", is shown as written.
+func escapeMarkdownCell(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "&", "&amp;")
+	text = strings.ReplaceAll(text, "<", "&lt;")
+	text = strings.ReplaceAll(text, "\r", " ")
+	return strings.ReplaceAll(text, "\n", " ")
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// TemplateData is the data a user-defined template is rendered over. It holds
+// the same values PlanFormatter.Format receives.
+type TemplateData struct {
+	Shifts  []types.OnCall
+	UserMap map[string]string
+	Start   time.Time
+	End     time.Time
+}
+
+// TemplateFormatter formats plan output with a user-defined text/template.
+//
+// Besides the builtin template functions, templates can use:
+//
+//	date LAYOUT TIME       format TIME with a Go layout, e.g. {{date "Mon 15:04" .Start}}
+//	tz ZONE TIME           convert TIME to an IANA zone, e.g. {{tz "Europe/Berlin" .Start}}
+//	duration START END     human-readable length, e.g. "1d 4h 30m"
+//	hours START END        length in hours as a number
+//	userName SHIFT         the shift's user name, looked up in .UserMap
+type TemplateFormatter struct {
+	tmpl *template.Template
+}
+
+// NewTemplateFormatter parses text as a template for plan output.
+func NewTemplateFormatter(text string) (*TemplateFormatter, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs(nil)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return &TemplateFormatter{tmpl: tmpl}, nil
+}
+
+// Format renders the template over the shifts.
+func (f *TemplateFormatter) Format(writer io.Writer, shifts []types.OnCall, userMap map[string]string, start, end time.Time) error {
+	data := TemplateData{
+		Shifts:  shifts,
+		UserMap: userMap,
+		Start:   start,
+		End:     end,
+	}
+
+	// Bind the functions on a copy so userName sees this call's user map,
+	// without affecting other calls to Format
+	tmpl, err := f.tmpl.Clone()
+	if err != nil {
+		return fmt.Errorf("error rendering template: %w", err)
+	}
+	if err := tmpl.Funcs(templateFuncs(userMap)).Execute(writer, data); err != nil {
+		return fmt.Errorf("error rendering template: %w", err)
+	}
+	return nil
+}
+
+// templateFuncs returns the helper functions available to user templates.
+func templateFuncs(userMap map[string]string) template.FuncMap {
+	return template.FuncMap{
+		"date": func(layout string, t time.Time) string {
+			return t.Format(layout)
+		},
+		"tz": func(zone string, t time.Time) (time.Time, error) {
+			loc, err := time.LoadLocation(zone)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("unknown time zone %q", zone)
+			}
+			return t.In(loc), nil
+		},
+		"duration": func(start, end time.Time) string {
+			return formatDuration(end.Sub(start))
+		},
+		"hours": func(start, end time.Time) float64 {
+			return end.Sub(start).Hours()
+		},
+		"userName": func(shift types.OnCall) string {
+			return shiftUserName(shift, userMap)
+		},
+	}
+}
+
+// formatDuration formats d as days, hours and minutes, omitting zero parts.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	return strings.Join(parts, " ")
+}
@@ -16,7 +16,11 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -110,7 +114,7 @@ func TestTextFormatter_Format_NoShifts(t *testing.T) {
 }
 
 func TestICalFormatter_Format(t *testing.T) {
-	formatter := NewICalFormatter()
+	formatter := NewICalFormatter(nil, "")
 
 	// Test data
 	start := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
@@ -151,7 +155,7 @@ func TestICalFormatter_Format(t *testing.T) {
 		"PRODID:-//myshift-go//ON-CALL SCHEDULE//EN",
 		"CALSCALE:GREGORIAN",
 		"BEGIN:VEVENT",
-		"UID:oncall-0-USER001@myshift-go",
+		"UID:oncall-SCHED001-USER001-20240315T090000Z@myshift-go",
 		"DTSTART:20240315T090000Z",
 		"DTEND:20240315T170000Z",
 		"SUMMARY:On-Call: John Doe",
@@ -176,7 +180,7 @@ func TestICalFormatter_Format(t *testing.T) {
 }
 
 func TestICalFormatter_Format_EmptyShifts(t *testing.T) {
-	formatter := NewICalFormatter()
+	formatter := NewICalFormatter(nil, "")
 
 	start := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
 	end := time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC)
@@ -210,7 +214,7 @@ func TestICalFormatter_Format_EmptyShifts(t *testing.T) {
 }
 
 func TestICalFormatter_EscapeText(t *testing.T) {
-	formatter := NewICalFormatter()
+	formatter := NewICalFormatter(nil, "")
 
 	tests := []struct {
 		input    string
@@ -233,6 +237,802 @@ func TestICalFormatter_EscapeText(t *testing.T) {
 	}
 }
 
+func TestICalFormatter_StableUpdates(t *testing.T) {
+	shift := types.OnCall{
+		Start:    time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC),
+		End:      time.Date(2024, 3, 22, 9, 0, 0, 0, time.UTC),
+		User:     types.User{ID: "USER001", Name: "John Doe"},
+		Schedule: types.Schedule{ID: "SCHED001", Name: "Primary"},
+	}
+	other := types.OnCall{
+		Start:    time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC),
+		End:      time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC),
+		User:     types.User{ID: "USER002", Name: "Jane Smith"},
+		Schedule: types.Schedule{ID: "SCHED001", Name: "Primary"},
+	}
+
+	// export renders shifts at generated, as last changed at changed
+	export := func(generated, changed time.Time, shifts ...types.OnCall) string {
+		formatter := NewICalFormatter(nil, "")
+		formatter.now = func() time.Time { return generated }
+		formatter.changed = func(types.OnCall) time.Time { return changed }
+		var buf bytes.Buffer
+		if err := formatter.Format(&buf, shifts, map[string]string{}, time.Time{}, time.Time{}); err != nil {
+			t.Fatalf("ICalFormatter.Format() failed: %v", err)
+		}
+		return buf.String()
+	}
+	sequence := func(output string) int {
+		match := regexp.MustCompile(`SEQUENCE:(\d+)\r\n`).FindStringSubmatch(output)
+		if match == nil {
+			t.Fatalf("Expected a SEQUENCE, got:\n%s", output)
+		}
+		n, _ := strconv.Atoi(match[1])
+		return n
+	}
+
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := export(created, time.Time{}, shift)
+
+	// The same shift keeps its UID when other shifts are added before it,
+	// and its SEQUENCE while it is unchanged
+	later := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)
+	unchanged := export(later, created, other, shift)
+
+	// A later export of a moved end has a higher SEQUENCE
+	moved := shift
+	moved.End = moved.End.Add(12 * time.Hour)
+	second := export(later, later, moved)
+
+	uid := "UID:oncall-SCHED001-USER001-20240315T090000Z@myshift-go"
+	if !strings.Contains(first, uid) || !strings.Contains(second, uid) {
+		t.Errorf("Expected %s in both exports, got:\n%s\n%s", uid, first, second)
+	}
+	if !strings.Contains(first, "DTSTAMP:20240301T120000Z\r\n") || !strings.Contains(first, "LAST-MODIFIED:20240301T120000Z\r\n") {
+		t.Errorf("Expected DTSTAMP and LAST-MODIFIED at the generation time, got:\n%s", first)
+	}
+	if !strings.Contains(unchanged, "DTSTAMP:20240302T120000Z\r\n") || !strings.Contains(unchanged, "LAST-MODIFIED:20240301T120000Z\r\n") {
+		t.Errorf("Expected LAST-MODIFIED to stay at the first export, got:\n%s", unchanged)
+	}
+	if got := sequence(first); got != 87120 {
+		t.Errorf("Expected SEQUENCE:87120 in the first export, got %d", got)
+	}
+	if sequence(unchanged) != sequence(first) {
+		t.Errorf("Expected the unchanged shift to keep SEQUENCE %d, got %d", sequence(first), sequence(unchanged))
+	}
+	if sequence(second) <= sequence(first) {
+		t.Errorf("Expected the moved shift's SEQUENCE to increase from %d, got %d", sequence(first), sequence(second))
+	}
+}
+
+func TestICalFormatter_TimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() failed: %v", err)
+	}
+
+	// Two shifts either side of the start of daylight saving time on 2024-03-10
+	schedule := types.Schedule{ID: "SCHED001", Name: "Primary", TimeZone: "America/New_York"}
+	shifts := []types.OnCall{
+		{
+			Start:    time.Date(2024, 3, 4, 9, 0, 0, 0, loc),
+			End:      time.Date(2024, 3, 8, 9, 0, 0, 0, loc),
+			User:     types.User{ID: "USER001"},
+			Schedule: schedule,
+		},
+		{
+			// Times in another zone are still written in the schedule's zone
+			Start:    time.Date(2024, 3, 11, 13, 0, 0, 0, time.UTC),
+			End:      time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC),
+			User:     types.User{ID: "USER002"},
+			Schedule: schedule,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewICalFormatter(nil, "").Format(&buf, shifts, map[string]string{}, time.Time{}, time.Time{}); err != nil {
+		t.Fatalf("ICalFormatter.Format() failed: %v", err)
+	}
+	output := buf.String()
+
+	want := strings.Join([]string{
+		"BEGIN:VTIMEZONE",
+		"TZID:America/New_York",
+		"BEGIN:STANDARD",
+		"DTSTART:20231105T020000",
+		"TZOFFSETFROM:-0400",
+		"TZOFFSETTO:-0500",
+		"TZNAME:EST",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20240310T020000",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0400",
+		"TZNAME:EDT",
+		"END:DAYLIGHT",
+		"END:VTIMEZONE",
+	}, "\r\n")
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected VTIMEZONE block:\n%s\ngot:\n%s", want, output)
+	}
+	for _, want := range []string{
+		"DTSTART;TZID=America/New_York:20240304T090000",
+		"DTSTART;TZID=America/New_York:20240311T090000",
+		"DTEND;TZID=America/New_York:20240315T090000",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Count(output, "BEGIN:VTIMEZONE") != 1 {
+		t.Errorf("Expected one VTIMEZONE, got:\n%s", output)
+	}
+}
+
+func TestICalFormatter_Alarms(t *testing.T) {
+	shifts := []types.OnCall{{
+		Start:    time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC),
+		End:      time.Date(2024, 3, 22, 9, 0, 0, 0, time.UTC),
+		User:     types.User{ID: "USER001", Name: "John Doe"},
+		Schedule: types.Schedule{ID: "SCHED001", Name: "Primary"},
+	}}
+
+	formatter := NewICalFormatter([]time.Duration{time.Hour, 15 * time.Minute}, "On-call: Primary, DB")
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, shifts, map[string]string{}, time.Time{}, time.Time{}); err != nil {
+		t.Fatalf("ICalFormatter.Format() failed: %v", err)
+	}
+	output := buf.String()
+
+	want := strings.Join([]string{
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:-PT1H",
+		"DESCRIPTION:On-call shift for John Doe starts in 1h",
+		"END:VALARM",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:-PT15M",
+		"DESCRIPTION:On-call shift for John Doe starts in 15m",
+		"END:VALARM",
+		"END:VEVENT",
+	}, "\r\n")
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected alarms:\n%s\ngot:\n%s", want, output)
+	}
+	if !strings.Contains(output, "X-WR-CALNAME:On-call: Primary\\, DB\r\n") {
+		t.Errorf("Expected calendar name, got:\n%s", output)
+	}
+}
+
+func TestParseAlarm(t *testing.T) {
+	tests := []struct {
+		value       string
+		want        time.Duration
+		wantTrigger string
+		wantErr     bool
+	}{
+		{value: "15m", want: 15 * time.Minute, wantTrigger: "PT15M"},
+		{value: "1h30m", want: 90 * time.Minute, wantTrigger: "PT1H30M"},
+		{value: "1d", want: 24 * time.Hour, wantTrigger: "P1D"},
+		{value: "1w", want: 7 * 24 * time.Hour, wantTrigger: "P7D"},
+		{value: "26h", want: 26 * time.Hour, wantTrigger: "P1DT2H"},
+		{value: "0s", want: 0, wantTrigger: "PT0S"},
+		{value: "-1h", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseAlarm(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAlarm(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseAlarm(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+			if trigger := icalDuration(got); trigger != tt.wantTrigger {
+				t.Errorf("icalDuration(%s) = %s, want %s", got, trigger, tt.wantTrigger)
+			}
+		})
+	}
+
+	if _, err := GetFormatter("csv", FormatOptions{Alarms: []string{"1h"}}); err == nil {
+		t.Error("Expected --alarm to be rejected for csv output")
+	}
+	if _, err := GetFormatter("ical", FormatOptions{Alarms: []string{"tomorrow"}}); err == nil {
+		t.Error("Expected an invalid --alarm to be rejected")
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	tests := []struct {
+		testName string
+		line     string
+		want     string
+	}{
+		{"short", "SUMMARY:On-Call", "SUMMARY:On-Call\r\n"},
+		{"exactly 75 octets", strings.Repeat("a", 75), strings.Repeat("a", 75) + "\r\n"},
+		{"folded", strings.Repeat("a", 160), strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n " + strings.Repeat("a", 11) + "\r\n"},
+		{"multi-octet character kept whole", strings.Repeat("a", 74) + "é", strings.Repeat("a", 74) + "\r\n é\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			got := foldICalLine(tt.line)
+			if got != tt.want {
+				t.Errorf("foldICalLine() = %q, want %q", got, tt.want)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+				if len(line) > icalLineLimit {
+					t.Errorf("Line of %d octets exceeds the limit: %q", len(line), line)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONFormatter_Format(t *testing.T) {
+	formatter := NewJSONFormatter()
+
+	start := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC)
+
+	shifts := []types.OnCall{
+		{
+			Start: time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 3, 15, 17, 0, 0, 0, time.UTC),
+			User: types.User{
+				ID:    "USER001",
+				Name:  "John Doe",
+				Email: "john@example.com",
+			},
+			Schedule: types.Schedule{
+				ID:   "SCHED001",
+				Name: "Primary Schedule",
+			},
+		},
+	}
+
+	userMap := map[string]string{
+		"USER001": "Johnny Doe",
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, shifts, userMap, start, end); err != nil {
+		t.Fatalf("JSONFormatter.Format() failed: %v", err)
+	}
+
+	var document JSONDocument
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if document.SchemaVersion != JSONSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", JSONSchemaVersion, document.SchemaVersion)
+	}
+	if !document.Start.Equal(start) || !document.End.Equal(end) {
+		t.Errorf("Expected range %v to %v, got %v to %v", start, end, document.Start, document.End)
+	}
+	if len(document.Shifts) != 1 {
+		t.Fatalf("Expected 1 shift, got %d", len(document.Shifts))
+	}
+
+	shift := document.Shifts[0]
+	if shift.Start == nil || !shift.Start.Equal(shifts[0].Start) || shift.End == nil || !shift.End.Equal(shifts[0].End) {
+		t.Errorf("Unexpected shift times: %v to %v", shift.Start, shift.End)
+	}
+	if shift.DurationSeconds == nil || *shift.DurationSeconds != 8*60*60 {
+		t.Errorf("Expected duration of 8 hours, got %v", shift.DurationSeconds)
+	}
+	if shift.User != (JSONUser{ID: "USER001", Name: "Johnny Doe", Email: "john@example.com"}) {
+		t.Errorf("Unexpected user: %+v", shift.User)
+	}
+	if shift.Schedule == nil || *shift.Schedule != (JSONReference{ID: "SCHED001", Name: "Primary Schedule"}) {
+		t.Errorf("Unexpected schedule: %+v", shift.Schedule)
+	}
+	if shift.EscalationPolicy != nil {
+		t.Errorf("Expected no escalation policy, got %+v", shift.EscalationPolicy)
+	}
+	if strings.Contains(buf.String(), "escalation") {
+		t.Errorf("Expected escalation fields to be omitted, got:\n%s", buf.String())
+	}
+}
+
+func TestJSONFormatter_Format_NoShifts(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJSONFormatter().Format(&buf, nil, nil, time.Now(), time.Now()); err != nil {
+		t.Fatalf("JSONFormatter.Format() failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"shifts": []`) {
+		t.Errorf("Expected an empty shifts array, got:\n%s", buf.String())
+	}
+}
+
+func TestDelimitedFormatter_Format(t *testing.T) {
+	start := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC)
+
+	shifts := []types.OnCall{
+		{
+			Start: time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 3, 15, 17, 30, 0, 0, time.UTC),
+			User: types.User{
+				ID:    "USER001",
+				Email: "john@example.com",
+			},
+			Schedule: types.Schedule{
+				ID:   "SCHED001",
+				Name: "Primary, \"Core\"",
+			},
+		},
+	}
+
+	userMap := map[string]string{
+		"USER001": "Doe, John",
+	}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		testName string
+		newFunc  func([]string, *time.Location) (*DelimitedFormatter, error)
+		columns  []string
+		location *time.Location
+		want     string
+	}{
+		{
+			testName: "csv with default columns",
+			newFunc:  NewCSVFormatter,
+			location: time.UTC,
+			want: "start (UTC),end (UTC),user,email,schedule,hours\r\n" +
+				"2024-03-15 09:00:00,2024-03-15 17:30:00,\"Doe, John\",john@example.com,\"Primary, \"\"Core\"\"\",8.50\r\n",
+		},
+		{
+			testName: "csv with chosen columns and location",
+			newFunc:  NewCSVFormatter,
+			columns:  []string{"user", "start", "hours"},
+			location: newYork,
+			want: "user,start (America/New_York),hours\r\n" +
+				"\"Doe, John\",2024-03-15 05:00:00,8.50\r\n",
+		},
+		{
+			testName: "tsv",
+			newFunc:  NewTSVFormatter,
+			columns:  []string{"email", "end"},
+			location: time.UTC,
+			want: "email\tend (UTC)\n" +
+				"john@example.com\t2024-03-15 17:30:00\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			formatter, err := tt.newFunc(tt.columns, tt.location)
+			if err != nil {
+				t.Fatalf("Failed to create formatter: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := formatter.Format(&buf, shifts, userMap, start, end); err != nil {
+				t.Fatalf("DelimitedFormatter.Format() failed: %v", err)
+			}
+
+			if buf.String() != tt.want {
+				t.Errorf("Unexpected output:\ngot:  %q\nwant: %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+// weeklyTestShifts returns three shifts: two users in the week of 2024-03-11
+// and the first user again in the week of 2024-03-18.
+func weeklyTestShifts() []types.OnCall {
+	return []types.OnCall{
+		{
+			Start:    time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC),
+			End:      time.Date(2024, 3, 12, 9, 0, 0, 0, time.UTC),
+			User:     types.User{ID: "USER001", Name: "John Doe"},
+			Schedule: types.Schedule{ID: "SCHED001", Name: "Primary | Core"},
+		},
+		{
+			Start:    time.Date(2024, 3, 17, 9, 0, 0, 0, time.UTC),
+			End:      time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC),
+			User:     types.User{ID: "USER002", Name: "Jane <Smith>"},
+			Schedule: types.Schedule{ID: "SCHED001", Name: "Primary | Core"},
+		},
+		{
+			Start:    time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC),
+			End:      time.Date(2024, 3, 19, 9, 0, 0, 0, time.UTC),
+			User:     types.User{ID: "USER001", Name: "John Doe"},
+			Schedule: types.Schedule{ID: "SCHED001", Name: "Primary | Core"},
+		},
+	}
+}
+
+func TestEscapeMarkdownCell(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Jane <Smith>", "Jane &lt;Smith>"},
+		{"Ops & Infra", "Ops &amp; Infra"},
+		{"&lt;b&gt; &amp;", "&amp;lt;b&amp;gt; &amp;amp;"},
+		{"a|b\\c\nd", "a\\|b\\\\c d"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeMarkdownCell(tt.text); got != tt.want {
+			t.Errorf("escapeMarkdownCell(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownFormatter_Format(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := NewMarkdownFormatter().Format(&buf, weeklyTestShifts(), map[string]string{}, start, end); err != nil {
+		t.Fatalf("MarkdownFormatter.Format() failed: %v", err)
+	}
+
+	want := `## On-call schedule: 2024-03-11 to 2024-03-25
+
+### Week of 2024-03-11
+
+| Start | End | User | Schedule |
+|-------|-----|------|----------|
+| 2024-03-11 09:00 UTC | 2024-03-12 09:00 UTC | John Doe | Primary \| Core |
+| 2024-03-17 09:00 UTC | 2024-03-18 09:00 UTC | Jane &lt;Smith> | Primary \| Core |
+
+### Week of 2024-03-18
+
+| Start | End | User | Schedule |
+|-------|-----|------|----------|
+| 2024-03-18 09:00 UTC | 2024-03-19 09:00 UTC | John Doe | Primary \| Core |
+`
+	if buf.String() != want {
+		t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestMarkdownFormatter_Format_NoShifts(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewMarkdownFormatter().Format(&buf, nil, nil, time.Now(), time.Now()); err != nil {
+		t.Fatalf("MarkdownFormatter.Format() failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No shifts found.") {
+		t.Errorf("Expected 'No shifts found.', got:\n%s", buf.String())
+	}
+}
+
+func TestHTMLFormatter_Format(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := NewHTMLFormatter().Format(&buf, weeklyTestShifts(), map[string]string{}, start, end); err != nil {
+		t.Fatalf("HTMLFormatter.Format() failed: %v", err)
+	}
+	output := buf.String()
+
+	expectedStrings := []string{
+		"<!DOCTYPE html>",
+		`<table id="shifts">`,
+		"<script>",
+		".user-0 { background: hsl(0, 65%, 85%); }",
+		".user-1 { background: hsl(137, 65%, 85%); }",
+		`<tr class="user-0"><td data-sort="2024-03-11T09:00:00Z">2024-03-11 09:00 UTC</td>`,
+		`<li class="user-1">Jane &lt;Smith&gt;</li>`,
+		`<td data-sort="24.00">24.00</td>`,
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	// Each user keeps one color: John's second shift reuses his class
+	if strings.Count(output, `<tr class="user-0">`) != 2 || strings.Contains(output, "user-2") {
+		t.Errorf("Expected two rows for user-0 and only two user classes, got:\n%s", output)
+	}
+
+	// Self-contained: nothing is loaded from elsewhere
+	for _, external := range []string{"<link", "src=", "http://", "https://"} {
+		if strings.Contains(output, external) {
+			t.Errorf("Expected no external resources, found %q", external)
+		}
+	}
+}
+
+func TestTemplateFormatter_Format(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)
+	userMap := map[string]string{"USER002": "Jane S."}
+
+	tests := []struct {
+		testName string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{
+			testName: "slack bullets",
+			template: `{{range .Shifts}}• {{userName .}}: {{date "Mon Jan 2 15:04" .Start}} ({{duration .Start .End}})
+{{end}}`,
+			want: "• John Doe: Mon Mar 11 09:00 (1d)\n• Jane S.: Sun Mar 17 09:00 (1d)\n• John Doe: Mon Mar 18 09:00 (1d)\n",
+		},
+		{
+			testName: "status bar line",
+			template: `{{with index .Shifts 0}}{{userName .}} until {{date "15:04 MST" (tz "America/New_York" .End)}}{{end}}`,
+			want:     "John Doe until 05:00 EDT",
+		},
+		{
+			testName: "range and hours",
+			template: `{{date "2006-01-02" .Start}}..{{date "2006-01-02" .End}}: {{range .Shifts}}{{hours .Start .End}} {{end}}`,
+			want:     "2024-03-11..2024-03-25: 24 24 24 ",
+		},
+		{
+			testName: "unknown time zone",
+			template: `{{range .Shifts}}{{tz "Mars/Olympus" .Start}}{{end}}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			formatter, err := NewTemplateFormatter(tt.template)
+			if err != nil {
+				t.Fatalf("NewTemplateFormatter() failed: %v", err)
+			}
+
+			var buf bytes.Buffer
+			err = formatter.Format(&buf, weeklyTestShifts(), userMap, start, end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TemplateFormatter.Format() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && buf.String() != tt.want {
+				t.Errorf("Unexpected output:\ngot:  %q\nwant: %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+// pausingWriter signals paused on its first write and waits for resume
+// before completing it.
+type pausingWriter struct {
+	bytes.Buffer
+	paused, resume chan struct{}
+}
+
+func (w *pausingWriter) Write(p []byte) (int, error) {
+	if w.paused != nil {
+		close(w.paused)
+		w.paused = nil
+		<-w.resume
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestTemplateFormatter_Format_Concurrent(t *testing.T) {
+	formatter, err := NewTemplateFormatter(`{{range .Shifts}}{{userName .}} {{end}}`)
+	if err != nil {
+		t.Fatalf("NewTemplateFormatter() failed: %v", err)
+	}
+	user := types.User{ID: "USER001", Name: "John Doe"}
+	shifts := []types.OnCall{{User: user}, {User: user}}
+
+	// A call paused part way through keeps its own user map while another
+	// call renders with a different one
+	paused := &pausingWriter{paused: make(chan struct{}), resume: make(chan struct{})}
+	waiting := paused.paused
+	done := make(chan error)
+	go func() {
+		done <- formatter.Format(paused, shifts, map[string]string{"USER001": "First"}, time.Time{}, time.Time{})
+	}()
+	<-waiting
+
+	var other bytes.Buffer
+	if err := formatter.Format(&other, shifts, map[string]string{"USER001": "Second"}, time.Time{}, time.Time{}); err != nil {
+		t.Fatalf("TemplateFormatter.Format() failed: %v", err)
+	}
+	close(paused.resume)
+	if err := <-done; err != nil {
+		t.Fatalf("TemplateFormatter.Format() failed: %v", err)
+	}
+
+	if got := paused.String(); got != "First First " {
+		t.Errorf("paused call rendered %q, want %q", got, "First First ")
+	}
+	if got := other.String(); got != "Second Second " {
+		t.Errorf("other call rendered %q, want %q", got, "Second Second ")
+	}
+}
+
+func TestNewTemplateFormatter_Invalid(t *testing.T) {
+	if _, err := NewTemplateFormatter("{{range .Shifts}}"); err == nil {
+		t.Error("Expected an error for an unterminated range")
+	}
+	if _, err := NewTemplateFormatter("{{nosuchfunc .}}"); err == nil {
+		t.Error("Expected an error for an unknown function")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, "0m"},
+		{45 * time.Minute, "45m"},
+		{8 * time.Hour, "8h"},
+		{26*time.Hour + 30*time.Minute, "1d 2h 30m"},
+		{7 * 24 * time.Hour, "7d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatDuration(tt.duration); got != tt.want {
+				t.Errorf("formatDuration(%v) = %q, want %q", tt.duration, got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestCalendarFormatter creates a calendar formatter with a fixed width
+// that treats its writer as a terminal or not.
+func newTestCalendarFormatter(noColor, terminal bool, width int) *CalendarFormatter {
+	return &CalendarFormatter{
+		noColor:    noColor,
+		isTerminal: func(io.Writer) bool { return terminal },
+		width:      func(io.Writer) int { return width },
+	}
+}
+
+func TestCalendarFormatter_Format(t *testing.T) {
+	start := time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	formatter := newTestCalendarFormatter(true, false, 120)
+	if err := formatter.Format(&buf, weeklyTestShifts(), map[string]string{}, start, end); err != nil {
+		t.Fatalf("CalendarFormatter.Format() failed: %v", err)
+	}
+
+	want := `On call from 2024-03-12 to 2024-03-20
++----------------+----------------+----------------+----------------+----------------+----------------+----------------+
+| Mon 03-11      | Tue 03-12      | Wed 03-13      | Thu 03-14      | Fri 03-15      | Sat 03-16      | Sun 03-17      |
++----------------+----------------+----------------+----------------+----------------+----------------+----------------+
+|                | John Doe       |                |                |                |                | >09:00 Jane <~ |
++----------------+----------------+----------------+----------------+----------------+----------------+----------------+
+| Mon 03-18      | Tue 03-19      | Wed 03-20      | Thu 03-21      | Fri 03-22      | Sat 03-23      | Sun 03-24      |
++----------------+----------------+----------------+----------------+----------------+----------------+----------------+
+| Jane <Smith>   | John Doe       |                |                |                |                |                |
+| >09:00 John D~ |                |                |                |                |                |                |
++----------------+----------------+----------------+----------------+----------------+----------------+----------------+
+`
+	if buf.String() != want {
+		t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestCalendarFormatter_Width(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		width     int
+		wantWidth int
+		wantText  string
+	}{
+		{width: 120, wantWidth: 120, wantText: ">09:00 Jane <~"},
+		{width: 80, wantWidth: 78, wantText: ">Jane <~"},
+		{width: 40, wantWidth: 64, wantText: "Mon 11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d columns", tt.width), func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := newTestCalendarFormatter(true, false, tt.width)
+			if err := formatter.Format(&buf, weeklyTestShifts(), map[string]string{}, start, end); err != nil {
+				t.Fatalf("CalendarFormatter.Format() failed: %v", err)
+			}
+
+			lines := strings.Split(buf.String(), "\n")
+			if got := len(lines[1]); got != tt.wantWidth {
+				t.Errorf("Expected grid %d columns wide, got %d:\n%s", tt.wantWidth, got, buf.String())
+			}
+			if !strings.Contains(buf.String(), tt.wantText) {
+				t.Errorf("Expected output to contain %q, got:\n%s", tt.wantText, buf.String())
+			}
+		})
+	}
+}
+
+func TestCalendarFormatter_Color(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		testName  string
+		noColor   bool
+		terminal  bool
+		wantColor bool
+		wantGrid  string
+	}{
+		{"terminal", false, true, true, "┌"},
+		{"pipe", false, false, false, "┌"},
+		{"NO_COLOR", true, true, false, "+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := newTestCalendarFormatter(tt.noColor, tt.terminal, 120)
+			if err := formatter.Format(&buf, weeklyTestShifts(), map[string]string{}, start, end); err != nil {
+				t.Fatalf("CalendarFormatter.Format() failed: %v", err)
+			}
+			output := buf.String()
+
+			if got := strings.Contains(output, "\x1b["); got != tt.wantColor {
+				t.Errorf("Expected color %v, got output:\n%s", tt.wantColor, output)
+			}
+			if tt.wantColor && (!strings.Contains(output, "\x1b[31mJohn Doe\x1b[0m") || !strings.Contains(output, "\x1b[32m↳09:00 Jane")) {
+				t.Errorf("Expected each user in their own color, got:\n%s", output)
+			}
+			if !strings.HasPrefix(strings.Split(output, "\n")[1], tt.wantGrid) {
+				t.Errorf("Expected grid drawn with %q, got:\n%s", tt.wantGrid, output)
+			}
+		})
+	}
+}
+
+func TestNewCalendarFormatter_NoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if !NewCalendarFormatter().noColor {
+		t.Error("Expected NO_COLOR to disable colors")
+	}
+
+	t.Setenv("NO_COLOR", "")
+	if NewCalendarFormatter().noColor {
+		t.Error("Expected an empty NO_COLOR to be ignored")
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "132")
+	if got := terminalWidth(&bytes.Buffer{}); got != 132 {
+		t.Errorf("Expected $COLUMNS to be used, got %d", got)
+	}
+
+	t.Setenv("COLUMNS", "")
+	if got := terminalWidth(&bytes.Buffer{}); got != defaultTerminalWidth {
+		t.Errorf("Expected default width %d, got %d", defaultTerminalWidth, got)
+	}
+}
+
+func TestDelimitedFormatter_Columns(t *testing.T) {
+	if _, err := NewCSVFormatter([]string{"start", "pager"}, time.UTC); err == nil {
+		t.Error("Expected an error for an unknown column")
+	}
+
+	if _, err := GetFormatter("text", FormatOptions{Columns: []string{"start"}}); err == nil {
+		t.Error("Expected an error for --columns with text output")
+	}
+
+	if _, err := GetFormatter("csv", FormatOptions{Columns: []string{"start", "user"}}); err != nil {
+		t.Errorf("GetFormatter() returned unexpected error: %v", err)
+	}
+}
+
 func TestGetFormatter(t *testing.T) {
 	tests := []struct {
 		format      string
@@ -245,14 +1045,22 @@ func TestGetFormatter(t *testing.T) {
 		{"ical", "*commands.ICalFormatter", false},
 		{"ics", "*commands.ICalFormatter", false},
 		{"ICAL", "*commands.ICalFormatter", false},
+		{"csv", "*commands.DelimitedFormatter", false},
+		{"markdown", "*commands.MarkdownFormatter", false},
+		{"md", "*commands.MarkdownFormatter", false},
+		{"html", "*commands.HTMLFormatter", false},
+		{"calendar", "*commands.CalendarFormatter", false},
+		{"template", "", true},
+		{"tsv", "*commands.DelimitedFormatter", false},
 		{"invalid", "", true},
-		{"json", "", true},
+		{"json", "*commands.JSONFormatter", false},
+		{"JSON", "*commands.JSONFormatter", false},
 		{"", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.format, func(t *testing.T) {
-			formatter, err := GetFormatter(tt.format)
+			formatter, err := GetFormatter(tt.format, FormatOptions{})
 
 			if tt.shouldError {
 				if err == nil {
@@ -15,15 +15,18 @@
 package commands
 
 import (
+	"context"
 	"io"
+	"os"
 
 	"github.com/jdcasey/myshift-go/internal/pagerduty"
 	"github.com/jdcasey/myshift-go/internal/types"
 )
 
-// Command represents a CLI command that can be executed
+// Command represents a CLI command that can be executed.
+// The context is cancelled when the user interrupts the command.
 type Command interface {
-	Execute(args []string) error
+	Execute(ctx context.Context, args []string) error
 	Usage() string
 }
 
@@ -32,6 +35,7 @@ type CommandContext struct {
 	Client pagerduty.PagerDutyClient
 	Config *types.Config
 	Writer io.Writer
+	Reader io.Reader // Source for interactive prompts; defaults to os.Stdin
 }
 
 // NewCommandContext creates a new command context
@@ -40,5 +44,6 @@ func NewCommandContext(client pagerduty.PagerDutyClient, config *types.Config, w
 		Client: client,
 		Config: config,
 		Writer: writer,
+		Reader: os.Stdin,
 	}
 }
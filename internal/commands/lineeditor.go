@@ -0,0 +1,556 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxHistory is the number of history entries kept.
+const maxHistory = 1000
+
+// errInterrupted is returned by lineEditor.ReadLine when the user presses Ctrl+C.
+var errInterrupted = errors.New("interrupted")
+
+// completer returns the candidates for completing the argument at the end of
+// line, and the byte offset in line where that argument starts.
+type completer func(line string) (candidates []string, start int)
+
+// key identifies a key read by the line editor.
+type key int
+
+const (
+	keyRune        key = iota // A character to insert
+	keyEnter                  // Enter or Ctrl+J
+	keyBackspace              // Backspace or Ctrl+H
+	keyDelete                 // Delete
+	keyLeft                   // Left arrow or Ctrl+B
+	keyRight                  // Right arrow or Ctrl+F
+	keyUp                     // Up arrow or Ctrl+P
+	keyDown                   // Down arrow or Ctrl+N
+	keyHome                   // Home or Ctrl+A
+	keyEnd                    // End or Ctrl+E
+	keyTab                    // Tab
+	keyInterrupt              // Ctrl+C
+	keyEOF                    // Ctrl+D
+	keyKillToEnd              // Ctrl+K
+	keyKillToStart            // Ctrl+U
+	keyDeleteWord             // Ctrl+W
+	keySearch                 // Ctrl+R
+	keyCancel                 // Ctrl+G or Escape
+	keyClear                  // Ctrl+L
+	keyUnknown                // Anything else, which is ignored
+)
+
+// controlKeys maps control characters to the keys they stand for.
+var controlKeys = map[rune]key{
+	0x01: keyHome,
+	0x02: keyLeft,
+	0x03: keyInterrupt,
+	0x04: keyEOF,
+	0x05: keyEnd,
+	0x06: keyRight,
+	0x07: keyCancel,
+	0x08: keyBackspace,
+	0x09: keyTab,
+	0x0a: keyEnter,
+	0x0b: keyKillToEnd,
+	0x0c: keyClear,
+	0x0d: keyEnter,
+	0x0e: keyDown,
+	0x10: keyUp,
+	0x12: keySearch,
+	0x15: keyKillToStart,
+	0x17: keyDeleteWord,
+	0x7f: keyBackspace,
+}
+
+// escapeKeys maps the final part of ANSI escape sequences, after "ESC [" or
+// "ESC O", to the keys they stand for.
+var escapeKeys = map[string]key{
+	"A":  keyUp,
+	"B":  keyDown,
+	"C":  keyRight,
+	"D":  keyLeft,
+	"H":  keyHome,
+	"F":  keyEnd,
+	"1~": keyHome,
+	"7~": keyHome,
+	"4~": keyEnd,
+	"8~": keyEnd,
+	"3~": keyDelete,
+}
+
+// lineEditor reads lines from a terminal in raw mode with readline-style
+// editing: cursor movement, history browsing, reverse history search (Ctrl+R)
+// and Tab completion. The caller puts the terminal into raw mode.
+type lineEditor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	history  *history
+	complete completer
+
+	// The line being edited
+	prompt string
+	buf    []rune
+	pos    int
+
+	// History browsing: the entry shown, and the line being edited before
+	// browsing started
+	historyIndex int
+	saved        []rune
+}
+
+// newLineEditor creates a line editor reading keys from in and drawing on out.
+func newLineEditor(in io.Reader, out io.Writer, history *history, complete completer) *lineEditor {
+	return &lineEditor{
+		in:       bufio.NewReader(in),
+		out:      out,
+		history:  history,
+		complete: complete,
+	}
+}
+
+// Input returns a reader over the editor's input for use between calls to
+// ReadLine, with the terminal in cooked mode. It starts with any input read
+// ahead of the current line, such as an answer pasted along with a command,
+// so that input reaches the command prompting for it.
+func (e *lineEditor) Input() io.Reader {
+	return &editorInput{in: e.in}
+}
+
+// editorInput reads from a line editor's buffered input. Lines typed ahead in
+// raw mode end with a carriage return rather than a newline, so a carriage
+// return is read as a newline, and a newline right after one is dropped.
+type editorInput struct {
+	in      *bufio.Reader
+	afterCR bool
+}
+
+// Read implements io.Reader.
+func (e *editorInput) Read(p []byte) (int, error) {
+	for {
+		n, err := e.in.Read(p)
+		j := 0
+		for _, c := range p[:n] {
+			if c == '\n' && e.afterCR {
+				e.afterCR = false
+				continue
+			}
+			e.afterCR = c == '\r'
+			if c == '\r' {
+				c = '\n'
+			}
+			p[j] = c
+			j++
+		}
+		if j > 0 || err != nil || n == 0 {
+			return j, err
+		}
+	}
+}
+
+// ReadLine shows prompt and returns the line the user enters. It returns
+// errInterrupted on Ctrl+C and io.EOF on Ctrl+D at an empty line or at the
+// end of input. Lines are not added to the history; that is up to the caller.
+func (e *lineEditor) ReadLine(prompt string) (string, error) {
+	e.prompt, e.buf, e.pos = prompt, nil, 0
+	e.historyIndex, e.saved = len(e.history.entries), nil
+	e.refresh()
+
+	for {
+		k, r, err := e.readKey()
+		if err != nil {
+			fmt.Fprint(e.out, "\r\n")
+			return "", err
+		}
+
+		switch k {
+		case keyEnter:
+			fmt.Fprint(e.out, "\r\n")
+			return string(e.buf), nil
+		case keyInterrupt:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupted
+		case keyEOF:
+			if len(e.buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			e.deleteRange(e.pos, e.pos+1)
+		case keyRune:
+			e.buf = append(e.buf[:e.pos], append([]rune{r}, e.buf[e.pos:]...)...)
+			e.pos++
+		case keyBackspace:
+			e.deleteRange(e.pos-1, e.pos)
+		case keyDelete:
+			e.deleteRange(e.pos, e.pos+1)
+		case keyLeft:
+			e.pos = max(e.pos-1, 0)
+		case keyRight:
+			e.pos = min(e.pos+1, len(e.buf))
+		case keyHome:
+			e.pos = 0
+		case keyEnd:
+			e.pos = len(e.buf)
+		case keyKillToEnd:
+			e.deleteRange(e.pos, len(e.buf))
+		case keyKillToStart:
+			e.deleteRange(0, e.pos)
+		case keyDeleteWord:
+			start := e.pos
+			for start > 0 && unicode.IsSpace(e.buf[start-1]) {
+				start--
+			}
+			for start > 0 && !unicode.IsSpace(e.buf[start-1]) {
+				start--
+			}
+			e.deleteRange(start, e.pos)
+		case keyUp:
+			e.browseHistory(-1)
+		case keyDown:
+			e.browseHistory(1)
+		case keyTab:
+			e.completeWord()
+		case keySearch:
+			submit, err := e.search()
+			if err != nil {
+				fmt.Fprint(e.out, "\r\n")
+				return "", err
+			}
+			if submit {
+				e.refresh()
+				fmt.Fprint(e.out, "\r\n")
+				return string(e.buf), nil
+			}
+		case keyClear:
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		}
+		e.refresh()
+	}
+}
+
+// readKey reads one key press. For keyRune, the rune is the character typed.
+func (e *lineEditor) readKey() (key, rune, error) {
+	r, _, err := e.in.ReadRune()
+	if err != nil {
+		return keyUnknown, 0, err
+	}
+
+	if r == 0x1b {
+		return e.readEscape()
+	}
+	if k, ok := controlKeys[r]; ok {
+		return k, r, nil
+	}
+	if unicode.IsControl(r) || r == utf8.RuneError {
+		return keyUnknown, r, nil
+	}
+	return keyRune, r, nil
+}
+
+// readEscape reads the rest of an escape sequence after ESC. A lone ESC,
+// with nothing following it already waiting, is keyCancel.
+func (e *lineEditor) readEscape() (key, rune, error) {
+	if e.in.Buffered() == 0 {
+		return keyCancel, 0x1b, nil
+	}
+	introducer, err := e.in.ReadByte()
+	if err != nil {
+		return keyUnknown, 0, err
+	}
+	if introducer != '[' && introducer != 'O' {
+		// Alt+key; ignored
+		return keyUnknown, rune(introducer), nil
+	}
+
+	// Parameters, then a final byte in the range @ to ~
+	var sequence []byte
+	for {
+		c, err := e.in.ReadByte()
+		if err != nil {
+			return keyUnknown, 0, err
+		}
+		if c >= '@' && c <= '~' {
+			if c != '~' {
+				// Modifiers such as "1;5" (Ctrl) come before the final byte
+				sequence = sequence[:0]
+			}
+			sequence = append(sequence, c)
+			break
+		}
+		sequence = append(sequence, c)
+	}
+
+	if k, ok := escapeKeys[string(sequence)]; ok {
+		return k, 0, nil
+	}
+	return keyUnknown, 0, nil
+}
+
+// deleteRange deletes the characters from start up to end, clamped to the
+// line, and moves the cursor to start.
+func (e *lineEditor) deleteRange(start, end int) {
+	start, end = max(start, 0), min(end, len(e.buf))
+	if start >= end {
+		return
+	}
+	e.buf = append(e.buf[:start], e.buf[end:]...)
+	e.pos = start
+}
+
+// setLine replaces the line being edited, with the cursor at its end.
+func (e *lineEditor) setLine(line []rune) {
+	e.buf = append([]rune(nil), line...)
+	e.pos = len(e.buf)
+}
+
+// browseHistory shows the history entry step entries away from the current
+// one. Moving past the newest entry returns to the line being edited.
+func (e *lineEditor) browseHistory(step int) {
+	index := e.historyIndex + step
+	if index < 0 || index > len(e.history.entries) {
+		return
+	}
+	if e.historyIndex == len(e.history.entries) {
+		e.saved = append([]rune(nil), e.buf...)
+	}
+
+	e.historyIndex = index
+	if index == len(e.history.entries) {
+		e.setLine(e.saved)
+	} else {
+		e.setLine([]rune(e.history.entries[index]))
+	}
+}
+
+// search runs a reverse incremental history search. Typing narrows the
+// search, Ctrl+R finds the next older match, Enter runs the match, Ctrl+G or
+// Ctrl+C gives up, and any other key leaves the match on the line for
+// editing. It reports whether the line should be submitted.
+func (e *lineEditor) search() (bool, error) {
+	original := append([]rune(nil), e.buf...)
+	var query []rune
+	match := -1
+
+	for {
+		e.drawSearch(string(query), match)
+
+		k, r, err := e.readKey()
+		if err != nil {
+			return false, err
+		}
+
+		switch k {
+		case keyRune:
+			query = append(query, r)
+			from := len(e.history.entries)
+			if match >= 0 {
+				// The current match may still match the longer query
+				from = match + 1
+			}
+			if found := e.history.search(string(query), from); found >= 0 {
+				match = found
+			}
+		case keyBackspace:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+				match = e.history.search(string(query), len(e.history.entries))
+			}
+		case keySearch:
+			if match >= 0 {
+				if found := e.history.search(string(query), match); found >= 0 {
+					match = found
+				}
+			}
+		case keyInterrupt, keyCancel:
+			e.setLine(original)
+			return false, nil
+		default:
+			if match >= 0 {
+				e.setLine([]rune(e.history.entries[match]))
+			}
+			return k == keyEnter, nil
+		}
+	}
+}
+
+// drawSearch redraws the line during a history search.
+func (e *lineEditor) drawSearch(query string, match int) {
+	label := "reverse-i-search"
+	line := ""
+	if match >= 0 {
+		line = e.history.entries[match]
+		if !strings.Contains(line, query) {
+			label = "failed " + label
+		}
+	} else if query != "" {
+		label = "failed " + label
+	}
+	e.draw(fmt.Sprintf("(%s)`%s': ", label, query), []rune(line), utf8.RuneCountInString(line))
+}
+
+// completeWord completes the argument before the cursor. A single candidate
+// is inserted in full, followed by a space; with several, their common prefix
+// is inserted, or they are listed if there is nothing to add.
+func (e *lineEditor) completeWord() {
+	if e.complete == nil {
+		return
+	}
+
+	head := string(e.buf[:e.pos])
+	candidates, start := e.complete(head)
+	if len(candidates) == 0 {
+		fmt.Fprint(e.out, "\a")
+		return
+	}
+
+	completion := commonPrefix(candidates)
+	if len(candidates) == 1 {
+		completion += " "
+	}
+	if len(completion) > len(head)-start {
+		head = head[:start] + completion
+		e.buf = append([]rune(head), e.buf[e.pos:]...)
+		e.pos = utf8.RuneCountInString(head)
+		return
+	}
+
+	fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+}
+
+// commonPrefix returns the longest prefix shared by all of values.
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
+
+// refresh redraws the prompt and the line being edited.
+func (e *lineEditor) refresh() {
+	e.draw(e.prompt, e.buf, e.pos)
+}
+
+// draw redraws the current terminal line as prompt followed by line, and
+// places the cursor before the character at pos. It assumes the line fits
+// the terminal width.
+func (e *lineEditor) draw(prompt string, line []rune, pos int) {
+	var screen bytes.Buffer
+	fmt.Fprintf(&screen, "\r%s%s\x1b[K", prompt, string(line))
+	if back := len(line) - pos; back > 0 {
+		fmt.Fprintf(&screen, "\x1b[%dD", back)
+	}
+	e.out.Write(screen.Bytes())
+}
+
+// history holds previously entered lines, oldest first. When path is set,
+// new entries are appended to that file as they are added.
+type history struct {
+	entries []string
+	path    string
+}
+
+// loadHistory reads the history kept in path, keeping the newest maxHistory
+// entries. A missing file is an empty history. Since entries are only ever
+// appended, a file that has grown past twice maxHistory entries is rewritten
+// with just the ones kept.
+func loadHistory(path string) (*history, error) {
+	h := &history{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, fmt.Errorf("error reading history: %w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if len(h.entries) <= maxHistory {
+		return h, nil
+	}
+
+	grown := len(h.entries) > 2*maxHistory
+	h.entries = h.entries[len(h.entries)-maxHistory:]
+	if grown {
+		content := strings.Join(h.entries, "\n") + "\n"
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			return h, fmt.Errorf("error trimming history: %w", err)
+		}
+	}
+	return h, nil
+}
+
+// add appends line, without surrounding whitespace, to the history unless it
+// is blank or repeats the newest entry, and saves it to the history file.
+func (h *history) add(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == line {
+		return nil
+	}
+
+	h.entries = append(h.entries, line)
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[len(h.entries)-maxHistory:]
+	}
+
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return fmt.Errorf("error saving history: %w", err)
+	}
+	file, err := os.OpenFile(h.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("error saving history: %w", err)
+	}
+	defer file.Close()
+	if _, err := fmt.Fprintln(file, line); err != nil {
+		return fmt.Errorf("error saving history: %w", err)
+	}
+	return nil
+}
+
+// search returns the index of the newest entry before index before that
+// contains query, or -1 if there is none.
+func (h *history) search(query string, before int) int {
+	for i := before - 1; i >= 0; i-- {
+		if strings.Contains(h.entries[i], query) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readEditedLine feeds input to a line editor with the given history and
+// returns the line read.
+func readEditedLine(t *testing.T, input string, entries []string, complete completer) (string, string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	editor := newLineEditor(strings.NewReader(input), &out, &history{entries: entries}, complete)
+	line, err := editor.ReadLine("> ")
+	return line, out.String(), err
+}
+
+func TestLineEditor_ReadLine(t *testing.T) {
+	entries := []string{"plan --days 7", "next", "upcoming --days 14"}
+
+	tests := []struct {
+		testName string
+		input    string
+		want     string
+		wantErr  error
+	}{
+		{"plain line", "next\r", "next", nil},
+		{"newline ends the line", "next\n", "next", nil},
+		{"unicode", "plan --user josé@example.com\r", "plan --user josé@example.com", nil},
+		{"backspace", "nexx\x7ft\r", "next", nil},
+		{"insert after moving left", "nxt\x1b[D\x1b[De\r", "next", nil},
+		{"home and end", "ext\x01n\x05!\r", "next!", nil},
+		{"home and end escape sequences", "ext\x1b[Hn\x1b[F!\r", "next!", nil},
+		{"delete under cursor", "nexxt\x1b[D\x1b[D\x1b[3~\r", "next", nil},
+		{"ctrl+d deletes under cursor", "nexxt\x1b[D\x1b[D\x04\r", "next", nil},
+		{"ctrl+left is left", "next\x1b[1;5D!\r", "nex!t", nil},
+		{"kill to end", "next --days 7\x1b[D\x1b[D\x0b\r", "next --days", nil},
+		{"kill to start", "bogus next\x1b[D\x1b[D\x1b[D\x1b[D\x15\r", "next", nil},
+		{"delete word", "next --days\x17\r", "next ", nil},
+		{"previous history entry", "\x1b[A\r", "upcoming --days 14", nil},
+		{"older history entry", "\x1b[A\x1b[A\x10\r", "plan --days 7", nil},
+		{"history stops at oldest", "\x1b[A\x1b[A\x1b[A\x1b[A\r", "plan --days 7", nil},
+		{"back to the edited line", "pl\x1b[A\x1b[B\r", "pl", nil},
+		{"edit history entry", "\x1b[A\x7f\x7f7\r", "upcoming --days 7", nil},
+		{"search history", "\x12days\r", "upcoming --days 14", nil},
+		{"search older match", "\x12days\x12\r", "plan --days 7", nil},
+		{"search then edit", "\x12nex\x1b[C!\r", "next!", nil},
+		{"cancel search", "pl\x12nex\x07\r", "pl", nil},
+		{"failed search keeps line", "\x12zzz\x1b[C\r", "", nil},
+		{"ctrl+c", "next\x03", "", errInterrupted},
+		{"ctrl+d on empty line", "\x04", "", io.EOF},
+		{"end of input", "nex", "", io.EOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			line, _, err := readEditedLine(t, tt.input, entries, nil)
+			if err != tt.wantErr {
+				t.Fatalf("ReadLine() error = %v, want %v", err, tt.wantErr)
+			}
+			if line != tt.want {
+				t.Errorf("ReadLine() = %q, want %q", line, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineEditor_Complete(t *testing.T) {
+	complete := func(line string) ([]string, int) {
+		start := strings.LastIndex(line, " ") + 1
+		var candidates []string
+		for _, option := range []string{"--schedule", "--start", "--days"} {
+			if strings.HasPrefix(option, line[start:]) {
+				candidates = append(candidates, option)
+			}
+		}
+		return candidates, start
+	}
+
+	tests := []struct {
+		testName   string
+		input      string
+		want       string
+		wantOutput string
+	}{
+		{"single candidate", "plan --d\t7\r", "plan --days 7", ""},
+		{"common prefix", "plan --s\tc\t\r", "plan --schedule ", ""},
+		{"lists ambiguous candidates", "plan --st\x7f\t\r", "plan --s", "--schedule  --start"},
+		{"completes before the cursor", "plan --d 7\x1b[D\x1b[D\t\x7f\r", "plan --days 7", ""},
+		{"no candidates", "plan --x\t\r", "plan --x", "\a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			line, output, err := readEditedLine(t, tt.input, nil, complete)
+			if err != nil {
+				t.Fatalf("ReadLine() failed: %v", err)
+			}
+			if line != tt.want {
+				t.Errorf("ReadLine() = %q, want %q", line, tt.want)
+			}
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("expected %q in output %q", tt.wantOutput, output)
+			}
+		})
+	}
+}
+
+func TestLineEditor_Input(t *testing.T) {
+	// A command and the answer to its prompt, pasted together in raw mode,
+	// then a line typed in cooked mode
+	var out bytes.Buffer
+	editor := newLineEditor(strings.NewReader("swap\ry\r\nnext\n"), &out, &history{}, nil)
+
+	line, err := editor.ReadLine("> ")
+	if err != nil || line != "swap" {
+		t.Fatalf("ReadLine() = %q, %v; want %q", line, err, "swap")
+	}
+
+	input := editor.Input()
+	for _, want := range []string{"y", "next"} {
+		answer, err := readLine(input)
+		if err != nil || answer != want {
+			t.Errorf("readLine() = %q, %v; want %q", answer, err, want)
+		}
+	}
+	if _, err := readLine(input); err != io.EOF {
+		t.Errorf("readLine() at end of input: err = %v, want io.EOF", err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "myshift", "history")
+
+	h, err := loadHistory(path)
+	if err != nil {
+		t.Fatalf("loadHistory() of a missing file failed: %v", err)
+	}
+	for _, line := range []string{"next", "  ", "next", " plan --days 7 ", "next"} {
+		if err := h.add(line); err != nil {
+			t.Fatalf("add(%q) failed: %v", line, err)
+		}
+	}
+
+	want := []string{"next", "plan --days 7", "next"}
+	reloaded, err := loadHistory(path)
+	if err != nil {
+		t.Fatalf("loadHistory() failed: %v", err)
+	}
+	if strings.Join(reloaded.entries, "|") != strings.Join(want, "|") {
+		t.Errorf("reloaded history = %q, want %q", reloaded.entries, want)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("history file permissions = %o, want 600", perm)
+	}
+
+	if found := reloaded.search("next", len(reloaded.entries)); found != 2 {
+		t.Errorf("search() = %d, want newest match 2", found)
+	}
+	if found := reloaded.search("next", 2); found != 0 {
+		t.Errorf("search() before 2 = %d, want 0", found)
+	}
+}
+
+func TestLoadHistory_Trims(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	var content strings.Builder
+	for i := 0; i < 2*maxHistory+1; i++ {
+		content.WriteString("next\n")
+	}
+	content.WriteString("plan\n")
+	if err := os.WriteFile(path, []byte(content.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := loadHistory(path)
+	if err != nil {
+		t.Fatalf("loadHistory() failed: %v", err)
+	}
+	if len(h.entries) != maxHistory || h.entries[maxHistory-1] != "plan" {
+		t.Errorf("got %d entries ending in %q, want the newest %d", len(h.entries), h.entries[len(h.entries)-1], maxHistory)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != maxHistory {
+		t.Errorf("history file has %d lines after trimming, want %d", lines, maxHistory)
+	}
+}
@@ -16,8 +16,11 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
 )
 
 // NextCommand handles the "next" command functionality.
@@ -33,16 +36,31 @@ func NewNextCommand(ctx *CommandContext) *NextCommand {
 }
 
 // Execute runs the next command to show the next on-call shift for a user.
-func (n *NextCommand) Execute(args []string) error {
+func (n *NextCommand) Execute(ctx context.Context, args []string) error {
 	parser := NewFlagParser("next").
 		AddUserFlag("", "User email address (uses my_user from config if not provided)").
 		AddDaysFlag(90, "Number of days to look ahead").
+		AddScheduleFlag("Schedule alias or ID; repeat to combine schedules (uses the default schedule if not provided)").
+		AddFormatFlag("text", "Output format (text, ical, json, csv, tsv, markdown, html, template, calendar)").
+		AddColumnsFlag("Columns for csv and tsv output (default: start,end,user,email,schedule,hours)").
+		AddTemplateFlag("Template text, or the name of a template in the config, for template output").
+		AddAlarmFlag("Add a reminder this long before each shift for ical output, e.g. 1h,15m; repeatable").
+		AddTimeZoneFlag("Time zone for dates and times: an IANA name, local, or schedule (default: time_zone from config, else local)").
 		SetUsage(func() {
 			fmt.Print(`Usage: myshift next [options]
 
 Options:
   --user string   User email address (uses my_user from config if not provided)
   --days int      Number of days to look ahead (default: 90)
+  --schedule string  Schedule alias or ID; repeat to combine schedules
+  --format, -o string  Output format: text, ical, json, csv, tsv, markdown, html, template,
+                       calendar (default: text)
+  --columns string     Columns for csv and tsv output (default: start,end,user,email,schedule,hours)
+  --template string    Template text, or the name of a template in the config, for template output
+  --alarm string       Add a reminder this long before each shift for ical output,
+                       e.g. 1h,15m; repeatable
+  --tz string          Time zone: an IANA name (e.g. Europe/Berlin), local, or schedule
+                       (default: time_zone from config, else local)
 
 `)
 		})
@@ -57,51 +75,74 @@ Options:
 		return nil
 	}
 
-	// Get schedule ID
-	scheduleID, err := n.GetScheduleID()
+	// Resolve schedule aliases to IDs
+	scheduleIDs, err := n.ResolveScheduleIDs(flags.Schedules)
+	if err != nil {
+		return err
+	}
+
+	// Shifts are shown in the chosen time zone
+	loc, err := n.Location(ctx, flags.TimeZone, scheduleIDs[0])
+	if err != nil {
+		return err
+	}
+
+	// Get the appropriate formatter; text output keeps its own wording below
+	formatter, err := GetFormatter(flags.Format, n.FormatOptions(flags, loc))
 	if err != nil {
 		return err
 	}
+	_, textOutput := formatter.(*TextFormatter)
 
 	// Find user by email
-	user, err := n.ResolveUser(flags.User)
+	user, err := n.ResolveUser(ctx, flags.User)
 	if err != nil {
 		return err
 	}
 
 	// Calculate time range
-	now := time.Now()
+	now := time.Now().In(loc)
 	until := now.AddDate(0, 0, flags.Days)
 
 	// Get on-call shifts
-	onCalls, err := n.GetOnCallsForUser(scheduleID, user.ID, now, until)
+	onCalls, err := n.GetOnCallsForUser(ctx, scheduleIDs, user.ID, now, until)
 	if err != nil {
 		return err
 	}
+	onCalls = ShiftsIn(onCalls, loc)
 
-	if len(onCalls) == 0 {
-		fmt.Fprintln(n.writer, "No upcoming shifts found")
-		return nil
-	}
-
-	// Find the next shift
-	nextShift := onCalls[0]
+	// Find the next shift that has not ended yet
+	var next []types.OnCall
 	for _, shift := range onCalls {
-		if shift.Start.Before(nextShift.Start) {
-			nextShift = shift
+		if shift.End.After(now) && (len(next) == 0 || shift.Start.Before(next[0].Start)) {
+			next = []types.OnCall{shift}
 		}
 	}
 
+	if !textOutput {
+		userMap := map[string]string{user.ID: user.Name}
+		return formatter.Format(n.writer, next, userMap, now, until)
+	}
+
+	if len(next) == 0 {
+		fmt.Fprintln(n.writer, "No upcoming shifts found")
+		return nil
+	}
+	nextShift := next[0]
+
 	// Check if currently on call
 	if nextShift.Start.Before(now) && nextShift.End.After(now) {
 		fmt.Fprintln(n.writer, "Currently on call")
 		fmt.Fprintf(n.writer, "Shift ends: %s\n", nextShift.End.Format("2006-01-02 15:04 MST"))
-	} else if nextShift.Start.After(now) {
+	} else {
 		fmt.Fprintln(n.writer, "Next shift:")
 		fmt.Fprintf(n.writer, "Starts: %s\n", nextShift.Start.Format("2006-01-02 15:04 MST"))
 		fmt.Fprintf(n.writer, "Ends: %s\n", nextShift.End.Format("2006-01-02 15:04 MST"))
-	} else {
-		fmt.Fprintln(n.writer, "No upcoming shifts found")
+	}
+
+	// Name the schedule when several were searched
+	if len(scheduleIDs) > 1 {
+		fmt.Fprintf(n.writer, "Schedule: %s\n", nextShift.Schedule.Name)
 	}
 
 	return nil
@@ -114,6 +155,15 @@ func (n *NextCommand) Usage() string {
 Options:
   --user string   User email address (uses my_user from config if not provided)
   --days int      Number of days to look ahead (default: 90)
+  --schedule string  Schedule alias or ID; repeat to combine schedules
+  --format, -o string  Output format: text, ical, json, csv, tsv, markdown, html, template,
+                       calendar (default: text)
+  --columns string     Columns for csv and tsv output (default: start,end,user,email,schedule,hours)
+  --template string    Template text, or the name of a template in the config, for template output
+  --alarm string       Add a reminder this long before each shift for ical output,
+                       e.g. 1h,15m; repeatable
+  --tz string          Time zone: an IANA name (e.g. Europe/Berlin), local, or schedule
+                       (default: time_zone from config, else local)
 
 `
 }
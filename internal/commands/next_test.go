@@ -15,6 +15,7 @@
 package commands
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -96,6 +97,37 @@ func TestNextCommand_Execute(t *testing.T) {
 				"Starts:",
 			},
 		},
+		{
+			testName: "json format",
+			args:     []string{"--user", "john@example.com", "--format", "json"},
+			setupMock: func(mock *MockPagerDutyClient, now time.Time) {
+				tomorrow := now.Add(24 * time.Hour)
+				mock.AddOnCall("USER001", "John Doe", "john@example.com",
+					tomorrow, tomorrow.Add(8*time.Hour))
+			},
+			wantErr: false,
+			wantOutput: []string{
+				`"schema_version": 1`,
+				`"email": "john@example.com"`,
+				`"duration_seconds": 28800`,
+			},
+		},
+		{
+			testName: "json format with no upcoming shifts",
+			args:     []string{"--user", "john@example.com", "--format", "json"},
+			setupMock: func(mock *MockPagerDutyClient, now time.Time) {
+				// User exists but no shifts added
+			},
+			wantErr:    false,
+			wantOutput: []string{`"shifts": []`},
+		},
+		{
+			testName: "unsupported format",
+			args:     []string{"--user", "john@example.com", "--format", "xml"},
+			setupMock: func(mock *MockPagerDutyClient, now time.Time) {
+			},
+			wantErr: true,
+		},
 		{
 			testName: "uses default user from config",
 			args:     []string{},
@@ -121,7 +153,7 @@ func TestNextCommand_Execute(t *testing.T) {
 			cmd := NewNextCommand(fixture.Context)
 
 			// Execute
-			err := cmd.Execute(tt.args)
+			err := cmd.Execute(context.Background(), tt.args)
 
 			// Verify error expectation
 			if (err != nil) != tt.wantErr {
@@ -151,7 +183,7 @@ func TestNextCommand_Execute_TimeHandling(t *testing.T) {
 
 	cmd := NewNextCommand(fixture.Context)
 
-	err := cmd.Execute([]string{"--user", "john@example.com"})
+	err := cmd.Execute(context.Background(), []string{"--user", "john@example.com"})
 
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
@@ -195,7 +227,7 @@ func TestNextCommand_Execute_ValidationErrors(t *testing.T) {
 
 			cmd := NewNextCommand(fixture.Context)
 
-			err := cmd.Execute(tt.args)
+			err := cmd.Execute(context.Background(), tt.args)
 
 			if err == nil {
 				t.Error("Expected error but got none")
@@ -218,6 +250,6 @@ func BenchmarkNextCommand_Execute(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		fixture.ClearOutput()
-		_ = cmd.Execute([]string{"--user", "john@example.com"})
+		_ = cmd.Execute(context.Background(), []string{"--user", "john@example.com"})
 	}
 }
@@ -0,0 +1,201 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/pagerduty"
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// NowCommand handles the "now" command functionality.
+type NowCommand struct {
+	*BaseCommand
+}
+
+// NewNowCommand creates a new NowCommand instance.
+func NewNowCommand(ctx *CommandContext) *NowCommand {
+	return &NowCommand{
+		BaseCommand: NewBaseCommand(ctx.Client, ctx.Config, ctx.Writer),
+	}
+}
+
+// Execute runs the now command to show who is on call at this moment, across
+// all schedules and escalation levels unless filtered.
+func (n *NowCommand) Execute(ctx context.Context, args []string) error {
+	flags, err := ParseNowFlags(args)
+	if err != nil {
+		return err
+	}
+
+	// If flags is nil, help was displayed - exit gracefully
+	if flags == nil {
+		return nil
+	}
+
+	format := strings.ToLower(flags.Format)
+	if format != "text" && format != "txt" && format != "json" {
+		return fmt.Errorf("unsupported format: %s (supported: text, json)", flags.Format)
+	}
+
+	// Unlike other commands, no --schedule means every schedule, not the default one
+	var scheduleIDs []string
+	if len(flags.Schedules) > 0 {
+		if scheduleIDs, err = n.ResolveScheduleIDs(flags.Schedules); err != nil {
+			return err
+		}
+	}
+
+	// With several schedules, "schedule" uses the first one's time zone
+	var scheduleID string
+	if len(scheduleIDs) > 0 {
+		scheduleID = scheduleIDs[0]
+	}
+	loc, err := n.Location(ctx, flags.TimeZone, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().In(loc)
+	onCalls, err := n.GetCurrentOnCalls(ctx, scheduleIDs, flags.EscalationPolicies, now)
+	if err != nil {
+		return err
+	}
+	onCalls = ShiftsIn(onCalls, loc)
+
+	if format == "json" {
+		return NewJSONFormatter().Format(n.writer, onCalls, nil, now, now)
+	}
+	return writeCurrentOnCallsText(n.writer, onCalls)
+}
+
+// GetCurrentOnCalls fetches everyone on call at now, at every escalation
+// level, optionally limited to some schedules and escalation policies. Entries
+// are grouped by schedule, escalation policy and level.
+func (b *BaseCommand) GetCurrentOnCalls(ctx context.Context, scheduleIDs, policyIDs []string, now time.Time) ([]types.OnCall, error) {
+	params := pagerduty.NewParamsBuilder().
+		TimeRange(now, now).
+		Overflow(true).
+		Schedules(scheduleIDs...).
+		EscalationPolicies(policyIDs...).
+		Include("users", "schedules").
+		Build()
+
+	onCalls, err := b.client.GetOnCalls(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching on-call users: %w", err)
+	}
+	return sortCurrentOnCalls(deduplicateCurrentOnCalls(onCalls)), nil
+}
+
+// deduplicateCurrentOnCalls removes repeated entries for the same user at the
+// same schedule, escalation policy and level. Unlike DeduplicateOnCalls it keeps
+// a user who appears at several levels or policies, since each is a separate
+// route for a page.
+func deduplicateCurrentOnCalls(onCalls []types.OnCall) []types.OnCall {
+	seen := make(map[string]bool)
+	var unique []types.OnCall
+	for _, onCall := range onCalls {
+		key := fmt.Sprintf("%s-%s-%s-%d", onCall.User.ID, onCall.Schedule.ID, onCall.EscalationPolicy.ID, onCall.EscalationLevel)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, onCall)
+		}
+	}
+	return unique
+}
+
+// sortCurrentOnCalls groups entries by schedule, then escalation policy and
+// level, then user name.
+func sortCurrentOnCalls(onCalls []types.OnCall) []types.OnCall {
+	sort.SliceStable(onCalls, func(i, j int) bool {
+		a, b := onCalls[i], onCalls[j]
+		if scheduleLabel(a.Schedule) != scheduleLabel(b.Schedule) {
+			return scheduleLabel(a.Schedule) < scheduleLabel(b.Schedule)
+		}
+		if a.EscalationPolicy.Summary != b.EscalationPolicy.Summary {
+			return a.EscalationPolicy.Summary < b.EscalationPolicy.Summary
+		}
+		if a.EscalationLevel != b.EscalationLevel {
+			return a.EscalationLevel < b.EscalationLevel
+		}
+		return a.User.Name < b.User.Name
+	})
+	return onCalls
+}
+
+// scheduleLabel names the schedule of an on-call entry. Entries without a
+// schedule come from an escalation rule that targets the user directly.
+func scheduleLabel(schedule types.Schedule) string {
+	switch {
+	case schedule.Name != "":
+		return schedule.Name
+	case schedule.ID != "":
+		return schedule.ID
+	default:
+		return "(direct)"
+	}
+}
+
+// shiftEndLabel formats when an on-call entry ends; permanent entries have no end.
+func shiftEndLabel(end time.Time) string {
+	if end.IsZero() {
+		return "-"
+	}
+	return end.Format("2006-01-02 15:04 MST")
+}
+
+// writeCurrentOnCallsText writes the current on-call users as a table.
+func writeCurrentOnCallsText(writer io.Writer, onCalls []types.OnCall) error {
+	if len(onCalls) == 0 {
+		_, err := fmt.Fprintln(writer, "Nobody is on call")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCHEDULE\tESCALATION POLICY\tLEVEL\tNAME\tEMAIL\tSHIFT ENDS")
+	for _, onCall := range onCalls {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			scheduleLabel(onCall.Schedule),
+			onCall.EscalationPolicy.Summary,
+			onCall.EscalationLevel,
+			onCall.User.Name,
+			onCall.User.Email,
+			shiftEndLabel(onCall.End))
+	}
+	return tw.Flush()
+}
+
+// Usage returns the usage information for the now command
+func (n *NowCommand) Usage() string {
+	return `Usage: myshift now [options]
+
+Show who is on call right now, for every schedule and escalation level.
+
+Options:
+  --schedule string           Only show these schedules (alias or ID); repeatable
+  --escalation-policy string  Only show these escalation policy IDs; repeatable
+  --tz string                 Time zone for shift end times: an IANA name, local, or schedule
+  --format, -o string         Output format: text, json (default: text)
+
+`
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// addCurrentOnCalls adds on-call entries around the fixture's current time:
+// two levels of the Ops policy on two schedules, and one finished shift.
+func addCurrentOnCalls(fixture *TestFixture) {
+	now := time.Now()
+	mock := fixture.MockClient
+
+	mock.AddOnCallForSchedule("SCHED1", "Primary", "USER002", "Jane Smith", "jane@example.com",
+		now.Add(-2*time.Hour), now.Add(6*time.Hour))
+	mock.SetLastEscalation("EP1", "Ops", 1)
+	mock.AddOnCallForSchedule("SCHED2", "Secondary", "USER003", "Bob Jones", "bob@example.com",
+		now.Add(-2*time.Hour), now.Add(30*time.Hour))
+	mock.SetLastEscalation("EP1", "Ops", 2)
+	mock.AddOnCallForSchedule("SCHED3", "Database", "USER004", "Ann Lee", "ann@example.com",
+		now.Add(-1*time.Hour), now.Add(2*time.Hour))
+	mock.SetLastEscalation("EP2", "Data", 1)
+	mock.AddOnCallForSchedule("SCHED1", "Primary", "USER001", "John Doe", "john@example.com",
+		now.Add(-10*time.Hour), now.Add(-2*time.Hour))
+	mock.SetLastEscalation("EP1", "Ops", 1)
+}
+
+func TestNowCommand_Execute(t *testing.T) {
+	tests := []struct {
+		testName         string
+		args             []string
+		wantErr          bool
+		expectedOutput   []string
+		unexpectedOutput []string
+		wantScheduleIDs  []string
+	}{
+		{
+			testName: "everyone on call",
+			args:     []string{},
+			expectedOutput: []string{
+				"SCHEDULE", "ESCALATION POLICY", "LEVEL", "SHIFT ENDS",
+				"Jane Smith", "jane@example.com",
+				"Bob Jones", "bob@example.com",
+				"Ann Lee", "ann@example.com",
+			},
+			unexpectedOutput: []string{"John Doe"},
+		},
+		{
+			testName:         "filter by schedule alias",
+			args:             []string{"--schedule", "db"},
+			expectedOutput:   []string{"Ann Lee"},
+			unexpectedOutput: []string{"Jane Smith", "Bob Jones"},
+			wantScheduleIDs:  []string{"SCHED3"},
+		},
+		{
+			testName:         "filter by escalation policy",
+			args:             []string{"--escalation-policy", "EP1"},
+			expectedOutput:   []string{"Jane Smith", "Bob Jones"},
+			unexpectedOutput: []string{"Ann Lee"},
+		},
+		{
+			testName: "unsupported format",
+			args:     []string{"--format", "xml"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			fixture.Config.Schedules = map[string]string{"db": "SCHED3"}
+			addCurrentOnCalls(fixture)
+
+			cmd := NewNowCommand(fixture.Context)
+			err := cmd.Execute(context.Background(), tt.args)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NowCommand.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			params := fixture.MockClient.GetOnCallsCalls[0]
+			if params.Get("since") != params.Get("until") {
+				t.Errorf("Expected since == until, got %s and %s", params.Get("since"), params.Get("until"))
+			}
+			if got := params["schedule_ids[]"]; strings.Join(got, ",") != strings.Join(tt.wantScheduleIDs, ",") {
+				t.Errorf("Expected schedule_ids[] %v, got %v", tt.wantScheduleIDs, got)
+			}
+
+			if !fixture.ContainsOutput(tt.expectedOutput...) {
+				t.Errorf("Expected output to contain %v, got:\n%s", tt.expectedOutput, fixture.GetOutput())
+			}
+			for _, unexpected := range tt.unexpectedOutput {
+				if fixture.ContainsOutput(unexpected) {
+					t.Errorf("Expected output not to contain %q, got:\n%s", unexpected, fixture.GetOutput())
+				}
+			}
+		})
+	}
+}
+
+func TestNowCommand_Grouping(t *testing.T) {
+	fixture := NewTestFixture()
+	addCurrentOnCalls(fixture)
+
+	cmd := NewNowCommand(fixture.Context)
+	if err := cmd.Execute(context.Background(), nil); err != nil {
+		t.Fatalf("NowCommand.Execute() failed: %v", err)
+	}
+
+	// Rows are ordered by schedule, then policy and level
+	output := fixture.GetOutput()
+	ann := strings.Index(output, "Ann Lee")
+	jane := strings.Index(output, "Jane Smith")
+	bob := strings.Index(output, "Bob Jones")
+	if !(ann < jane && jane < bob) {
+		t.Errorf("Expected Database, Primary, Secondary order, got:\n%s", output)
+	}
+}
+
+func TestNowCommand_JSON(t *testing.T) {
+	fixture := NewTestFixture()
+	addCurrentOnCalls(fixture)
+
+	cmd := NewNowCommand(fixture.Context)
+	if err := cmd.Execute(context.Background(), []string{"--format", "json"}); err != nil {
+		t.Fatalf("NowCommand.Execute() failed: %v", err)
+	}
+
+	var document JSONDocument
+	if err := json.Unmarshal(fixture.Buffer.Bytes(), &document); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, fixture.GetOutput())
+	}
+	if document.SchemaVersion != JSONSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", JSONSchemaVersion, document.SchemaVersion)
+	}
+	if len(document.Shifts) != 3 {
+		t.Fatalf("Expected 3 shifts, got %d", len(document.Shifts))
+	}
+
+	first := document.Shifts[0]
+	if first.Schedule == nil || first.Schedule.ID != "SCHED3" || first.EscalationPolicy == nil ||
+		first.EscalationPolicy.ID != "EP2" || first.EscalationLevel != 1 ||
+		first.User.Email != "ann@example.com" || first.End == nil {
+		t.Errorf("Unexpected first shift: %+v", first)
+	}
+}
+
+func TestNowCommand_NobodyOnCall(t *testing.T) {
+	fixture := NewTestFixture()
+
+	cmd := NewNowCommand(fixture.Context)
+	if err := cmd.Execute(context.Background(), nil); err != nil {
+		t.Fatalf("NowCommand.Execute() failed: %v", err)
+	}
+	if !fixture.ContainsOutput("Nobody is on call") {
+		t.Errorf("Expected 'Nobody is on call', got:\n%s", fixture.GetOutput())
+	}
+}
@@ -16,7 +16,10 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/jdcasey/myshift-go/internal/types"
 )
@@ -28,13 +31,30 @@ type OverrideCommand struct {
 
 // NewOverrideCommand creates a new OverrideCommand instance.
 func NewOverrideCommand(ctx *CommandContext) *OverrideCommand {
+	base := NewBaseCommand(ctx.Client, ctx.Config, ctx.Writer)
+	base.reader = ctx.Reader
 	return &OverrideCommand{
-		BaseCommand: NewBaseCommand(ctx.Client, ctx.Config, ctx.Writer),
+		BaseCommand: base,
 	}
 }
 
-// Execute runs the override command to create schedule overrides.
-func (o *OverrideCommand) Execute(args []string) error {
+// Execute runs the override command. With a "list" or "delete" subcommand it
+// manages existing overrides; otherwise it creates new schedule overrides.
+func (o *OverrideCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			return o.executeList(ctx, args[1:])
+		case "delete":
+			return o.executeDelete(ctx, args[1:])
+		}
+	}
+
+	return o.executeCreate(ctx, args)
+}
+
+// executeCreate creates overrides so that one user covers another's shifts.
+func (o *OverrideCommand) executeCreate(ctx context.Context, args []string) error {
 	flags, err := ParseOverrideFlags(args)
 	if err != nil {
 		return err
@@ -45,32 +65,38 @@ func (o *OverrideCommand) Execute(args []string) error {
 		return nil
 	}
 
-	// Get schedule ID
-	scheduleID, err := o.GetScheduleID()
+	// Resolve schedule alias to ID
+	scheduleID, err := o.ResolveScheduleID(flags.Schedule)
+	if err != nil {
+		return err
+	}
+
+	// Times are given, shown and sent to PagerDuty in the chosen time zone
+	loc, err := o.Location(ctx, flags.TimeZone, scheduleID)
 	if err != nil {
 		return err
 	}
 
 	// Parse time range
-	start, end, err := ParseTimeRange(flags.Start, flags.End)
+	start, end, err := ParseTimeRange(flags.Start, flags.End, loc)
 	if err != nil {
 		return err
 	}
 
 	// Find user who will take over the shift
-	user, err := o.client.FindUserByEmail(flags.User)
+	user, err := o.client.FindUserByEmail(ctx, flags.User)
 	if err != nil {
 		return fmt.Errorf("error finding user %s: %w", flags.User, err)
 	}
 
 	// Find target user whose shifts will be overridden
-	targetUser, err := o.client.FindUserByEmail(flags.Target)
+	targetUser, err := o.client.FindUserByEmail(ctx, flags.Target)
 	if err != nil {
 		return fmt.Errorf("error finding target user %s: %w", flags.Target, err)
 	}
 
 	// Get existing shifts for the target user in the time range
-	onCalls, err := o.GetOnCallsForUser(scheduleID, targetUser.ID, start, end)
+	onCalls, err := o.GetOnCallsForUser(ctx, []string{scheduleID}, targetUser.ID, start, end)
 	if err != nil {
 		return fmt.Errorf("error fetching target shifts: %w", err)
 	}
@@ -79,45 +105,146 @@ func (o *OverrideCommand) Execute(args []string) error {
 		return fmt.Errorf("no shifts found for target user %s in the specified time range", flags.Target)
 	}
 
-	// Create overrides for each shift
-	var overrides []types.Override
-	for _, shift := range onCalls {
-		override := types.Override{
-			Start: shift.Start,
-			End:   shift.End,
-			User: types.UserReference{
-				ID:   user.ID,
-				Type: "user_reference",
-			},
-			TimeZone: "UTC",
+	// Plan overrides for each shift, clipped to the requested window
+	planned := PlanOverrides(ShiftsIn(onCalls, loc), user, start, end, flags.WholeShifts, loc)
+	if len(planned) == 0 {
+		return fmt.Errorf("no shifts found for target user %s in the specified time range", flags.Target)
+	}
+
+	// Show exactly what will be submitted, from the same plan used for the request
+	WriteOverridePlan(o.writer, planned, scheduleID)
+
+	if flags.DryRun {
+		fmt.Fprintln(o.writer, "Dry run: no overrides were created")
+		return nil
+	}
+
+	if !flags.Yes {
+		if !o.CanPrompt() {
+			return fmt.Errorf("refusing to create overrides without confirmation (use --yes)")
+		}
+		confirmed, err := o.Confirm(fmt.Sprintf("Create %d override(s)?", len(planned)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(o.writer, "Aborted; no overrides were created")
+			return nil
 		}
-		overrides = append(overrides, override)
 	}
 
-	// Create the overrides
-	if err := o.client.CreateOverrides(scheduleID, overrides); err != nil {
+	// Create the overrides; after a partial failure, show the ones that
+	// exist so they can be kept or deleted
+	created, err := o.client.CreateOverrides(ctx, scheduleID, OverridesFromPlan(planned))
+	if err != nil {
+		if len(created) > 0 {
+			fmt.Fprintf(o.writer, "Created %d of %d override(s) before the error:\n", len(created), len(planned))
+			for _, override := range created {
+				fmt.Fprintf(o.writer, "  %s  %s\n", override.ID, FormatTimeRange(override.Start.In(loc), override.End.In(loc)))
+			}
+			fmt.Fprintf(o.writer, "Remove them with 'myshift override delete --schedule %s <id>...'\n", scheduleID)
+		}
 		return fmt.Errorf("error creating overrides: %w", err)
 	}
 
-	fmt.Fprintf(o.writer, "Successfully created %d override(s) for %s\n", len(overrides), user.Name)
-	for i, override := range overrides {
-		fmt.Fprintf(o.writer, "Override %d:\n", i+1)
-		fmt.Fprintf(o.writer, "  Start: %s\n", override.Start.Format("2006-01-02 15:04 MST"))
-		fmt.Fprintf(o.writer, "  End: %s\n", override.End.Format("2006-01-02 15:04 MST"))
-	}
+	fmt.Fprintf(o.writer, "Successfully created %d override(s) for %s\n", len(planned), user.Name)
 
 	return nil
 }
 
+// WriteOverridePlan describes planned overrides: the schedule, the original
+// shift and its owner, who will cover it, and the overridden portion.
+func WriteOverridePlan(writer io.Writer, planned []PlannedOverride, scheduleID string) {
+	scheduleName := scheduleID
+	if len(planned) > 0 && planned[0].Shift.Schedule.Name != "" {
+		scheduleName = fmt.Sprintf("%s (%s)", planned[0].Shift.Schedule.Name, scheduleID)
+	}
+
+	fmt.Fprintf(writer, "Overrides to create on schedule %s:\n", scheduleName)
+	for i, plan := range planned {
+		fmt.Fprintf(writer, "Override %d:\n", i+1)
+		fmt.Fprintf(writer, "  Shift: %s (%s)\n", FormatTimeRange(plan.Shift.Start, plan.Shift.End), plan.Shift.User.Name)
+		fmt.Fprintf(writer, "  Covered by: %s <%s>\n", plan.Cover.Name, plan.Cover.Email)
+		fmt.Fprintf(writer, "  Start: %s\n", plan.Override.Start.Format("2006-01-02 15:04 MST"))
+		fmt.Fprintf(writer, "  End: %s\n", plan.Override.End.Format("2006-01-02 15:04 MST"))
+	}
+}
+
+// PlannedOverride pairs an override with the on-call shift it covers and the
+// user who takes the shift over.
+type PlannedOverride struct {
+	Shift    types.OnCall
+	Cover    types.User
+	Override types.Override
+}
+
+// PlanOverrides builds the overrides that hand the given shifts to user. Each
+// override is clipped to the intersection of its shift and the requested
+// window, so covering a single afternoon does not hand over a week-long shift.
+// With wholeShifts, each override covers its entire shift instead. Shifts that
+// do not overlap the window are skipped. Overrides are created in loc, which
+// PagerDuty uses to show them.
+func PlanOverrides(shifts []types.OnCall, user *types.User, start, end time.Time, wholeShifts bool, loc *time.Location) []PlannedOverride {
+	var planned []PlannedOverride
+	for _, shift := range shifts {
+		overrideStart, overrideEnd := shift.Start, shift.End
+		if !wholeShifts {
+			if start.After(overrideStart) {
+				overrideStart = start
+			}
+			if end.Before(overrideEnd) {
+				overrideEnd = end
+			}
+		}
+		if !overrideEnd.After(overrideStart) {
+			continue
+		}
+
+		planned = append(planned, PlannedOverride{
+			Shift: shift,
+			Cover: *user,
+			Override: types.Override{
+				Start: overrideStart.In(loc),
+				End:   overrideEnd.In(loc),
+				User: types.UserReference{
+					ID:   user.ID,
+					Type: "user_reference",
+				},
+				TimeZone: timeZoneName(loc),
+			},
+		})
+	}
+	return planned
+}
+
+// OverridesFromPlan extracts the overrides to submit from a plan.
+func OverridesFromPlan(planned []PlannedOverride) []types.Override {
+	overrides := make([]types.Override, 0, len(planned))
+	for _, plan := range planned {
+		overrides = append(overrides, plan.Override)
+	}
+	return overrides
+}
+
 // Usage returns the usage information for the override command
 func (o *OverrideCommand) Usage() string {
 	return `Usage: myshift override [options]
+       myshift override list [--user email] [--since S] [--until U]
+       myshift override delete <id>... | --mine | --range [--yes]
 
 Options:
   --user string     User email to override with (required)
   --target string   Target user email to override (required)  
-  --start string    Start time (YYYY-MM-DD HH:MM) (required)
-  --end string      End time (YYYY-MM-DD HH:MM) (required)
+  --start string    Start time, e.g. '2025-01-15 09:00' or 'tomorrow 09:00' (required)
+  --end string      End time, e.g. '2025-01-15 17:00' or '+8h' after the start (required)
+  --schedule string Schedule alias or ID (uses the default schedule if not provided)
+  --tz string       Time zone for dates and times: an IANA name, local, or schedule
+  --whole-shifts    Override each affected shift in full instead of only the requested window
+  --dry-run         Show the overrides that would be created without creating them
+  --yes             Create the overrides without asking for confirmation
+
+Use 'myshift override list --help' or 'myshift override delete --help'
+for subcommand options.
 
 `
 }
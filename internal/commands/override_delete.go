@@ -0,0 +1,126 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// executeDelete deletes overrides selected by ID, by assigned user (--mine),
+// or by time range (--range), after asking for confirmation.
+func (o *OverrideCommand) executeDelete(ctx context.Context, args []string) error {
+	flags, err := ParseOverrideDeleteFlags(args)
+	if err != nil {
+		return err
+	}
+
+	// If flags is nil, help was displayed - exit gracefully
+	if flags == nil {
+		return nil
+	}
+
+	scheduleID, err := o.ResolveScheduleID(flags.Schedule)
+	if err != nil {
+		return err
+	}
+
+	loc, err := o.Location(ctx, flags.TimeZone, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	targets, err := o.selectOverridesForDelete(ctx, scheduleID, flags, loc)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		fmt.Fprintln(o.writer, "No matching overrides found")
+		return nil
+	}
+
+	fmt.Fprintf(o.writer, "The following %d override(s) will be deleted:\n", len(targets))
+	if len(flags.IDs) > 0 {
+		for _, override := range targets {
+			fmt.Fprintf(o.writer, "  %s\n", override.ID)
+		}
+	} else if err := writeOverridesTable(o.writer, targets, loc); err != nil {
+		return err
+	}
+
+	if !flags.Yes {
+		if !o.CanPrompt() {
+			return fmt.Errorf("refusing to delete overrides without confirmation (use --yes)")
+		}
+		confirmed, err := o.Confirm(fmt.Sprintf("Delete %d override(s)?", len(targets)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(o.writer, "Aborted; no overrides were deleted")
+			return nil
+		}
+	}
+
+	var failures []error
+	for _, override := range targets {
+		if err := o.client.DeleteOverride(ctx, scheduleID, override.ID); err != nil {
+			fmt.Fprintf(o.writer, "Failed to delete override %s: %v\n", override.ID, err)
+			failures = append(failures, err)
+			continue
+		}
+		fmt.Fprintf(o.writer, "Deleted override %s\n", override.ID)
+	}
+
+	// Wrap the failures so that exit codes and hints reflect their cause
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to delete %d of %d override(s): %w", len(failures), len(targets), errors.Join(failures...))
+	}
+	return nil
+}
+
+// selectOverridesForDelete returns the overrides chosen by the delete flags.
+// Overrides selected by ID are returned as-is, without a lookup. A range is
+// read as times in loc.
+func (o *OverrideCommand) selectOverridesForDelete(ctx context.Context, scheduleID string, flags *OverrideDeleteFlags, loc *time.Location) ([]types.Override, error) {
+	if len(flags.IDs) > 0 {
+		targets := make([]types.Override, 0, len(flags.IDs))
+		for _, id := range flags.IDs {
+			targets = append(targets, types.Override{ID: id})
+		}
+		return targets, nil
+	}
+
+	since, until, err := ParseOverrideRange(flags.Since, flags.Until, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := ""
+	if flags.Mine {
+		user, err := o.ResolveUser(ctx, flags.User)
+		if err != nil {
+			return nil, err
+		}
+		userID = user.ID
+	}
+
+	return o.listOverrides(ctx, scheduleID, since, until, userID)
+}
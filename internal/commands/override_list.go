@@ -0,0 +1,141 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// executeList lists the overrides on a schedule, optionally filtered by the assigned user.
+func (o *OverrideCommand) executeList(ctx context.Context, args []string) error {
+	flags, err := ParseOverrideListFlags(args)
+	if err != nil {
+		return err
+	}
+
+	// If flags is nil, help was displayed - exit gracefully
+	if flags == nil {
+		return nil
+	}
+
+	scheduleID, err := o.ResolveScheduleID(flags.Schedule)
+	if err != nil {
+		return err
+	}
+
+	loc, err := o.Location(ctx, flags.TimeZone, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	since, until, err := ParseOverrideRange(flags.Since, flags.Until, loc)
+	if err != nil {
+		return err
+	}
+
+	userID := ""
+	if flags.User != "" {
+		user, err := o.client.FindUserByEmail(ctx, flags.User)
+		if err != nil {
+			return fmt.Errorf("error finding user %s: %w", flags.User, err)
+		}
+		userID = user.ID
+	}
+
+	overrides, err := o.listOverrides(ctx, scheduleID, since, until, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(overrides) == 0 {
+		fmt.Fprintf(o.writer, "No overrides found from %s\n", FormatTimeRange(since, until))
+		return nil
+	}
+
+	return writeOverridesTable(o.writer, overrides, loc)
+}
+
+// listOverrides fetches the overrides on a schedule in a time range. When
+// userID is non-empty, only overrides assigned to that user are returned.
+func (o *OverrideCommand) listOverrides(ctx context.Context, scheduleID string, since, until time.Time, userID string) ([]types.Override, error) {
+	overrides, err := o.client.ListOverrides(ctx, scheduleID, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("error listing overrides: %w", err)
+	}
+
+	if userID == "" {
+		return overrides, nil
+	}
+
+	var filtered []types.Override
+	for _, override := range overrides {
+		if override.User.ID == userID {
+			filtered = append(filtered, override)
+		}
+	}
+	return filtered, nil
+}
+
+// writeOverridesTable writes overrides as an aligned table, with times in loc.
+func writeOverridesTable(writer io.Writer, overrides []types.Override, loc *time.Location) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTART\tEND\tUSER")
+	for _, override := range overrides {
+		userName := override.User.Summary
+		if userName == "" {
+			userName = override.User.ID
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			override.ID,
+			override.Start.In(loc).Format("2006-01-02 15:04 MST"),
+			override.End.In(loc).Format("2006-01-02 15:04 MST"),
+			userName)
+	}
+	return tw.Flush()
+}
+
+// ParseOverrideRange parses the --since and --until flags used by the override
+// subcommands as date expressions (see ParseDateExpression) in loc. since
+// defaults to now, and until defaults to 28 days after since.
+func ParseOverrideRange(sinceValue, untilValue string, loc *time.Location) (time.Time, time.Time, error) {
+	now := time.Now().In(loc)
+	since := now
+	if sinceValue != "" {
+		var err error
+		if since, err = ParseDateExpression(sinceValue, now); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+
+	until := since.AddDate(0, 0, 28)
+	if untilValue != "" {
+		var err error
+		if until, err = ParseDateExpression(untilValue, now); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+
+	if !until.After(since) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--until must be after --since")
+	}
+
+	return since, until, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/pagerduty"
+)
+
+// addTestOverrides adds overrides for two users on 2025-01-15 and 2025-01-20.
+func addTestOverrides(mock *MockPagerDutyClient) {
+	mock.AddUser("USER002", "Jane Smith", "jane@example.com")
+	day := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	mock.AddOverride("SCHED123", "PO1", "USER001", day, day.Add(8*time.Hour))
+	mock.AddOverride("SCHED123", "PO2", "USER002", day.AddDate(0, 0, 5), day.AddDate(0, 0, 5).Add(8*time.Hour))
+}
+
+func TestOverrideCommand_List(t *testing.T) {
+	tests := []struct {
+		testName    string
+		args        []string
+		wantErr     bool
+		wantOutput  []string
+		avoidOutput []string
+	}{
+		{
+			testName:   "all overrides in range",
+			args:       []string{"list", "--since", "2025-01-01", "--until", "2025-02-01"},
+			wantOutput: []string{"ID", "PO1", "PO2", "2025-01-15 09:00 UTC"},
+		},
+		{
+			testName:    "filter by user",
+			args:        []string{"list", "--user", "jane@example.com", "--since", "2025-01-01", "--until", "2025-02-01"},
+			wantOutput:  []string{"PO2"},
+			avoidOutput: []string{"PO1"},
+		},
+		{
+			testName:   "narrow range",
+			args:       []string{"list", "--since", "2025-01-18", "--until", "2025-01-19"},
+			wantOutput: []string{"No overrides found"},
+		},
+		{
+			testName: "invalid range",
+			args:     []string{"list", "--since", "2025-02-01", "--until", "2025-01-01"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			addTestOverrides(fixture.MockClient)
+
+			cmd := NewOverrideCommand(fixture.Context)
+
+			err := cmd.Execute(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OverrideCommand.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !fixture.ContainsOutput(tt.wantOutput...) {
+				t.Errorf("Expected output to contain %v, got:\n%s", tt.wantOutput, fixture.GetOutput())
+			}
+			for _, avoid := range tt.avoidOutput {
+				if strings.Contains(fixture.GetOutput(), avoid) {
+					t.Errorf("Expected output not to contain %q, got:\n%s", avoid, fixture.GetOutput())
+				}
+			}
+		})
+	}
+}
+
+func TestOverrideCommand_Delete(t *testing.T) {
+	tests := []struct {
+		testName    string
+		args        []string
+		input       string
+		wantErr     bool
+		wantDeleted []string
+		wantOutput  []string
+	}{
+		{
+			testName:    "delete by id with confirmation",
+			args:        []string{"delete", "PO2"},
+			input:       "y\n",
+			wantDeleted: []string{"PO2"},
+			wantOutput:  []string{"Delete 1 override(s)? [y/N]", "Deleted override PO2"},
+		},
+		{
+			testName:   "declined confirmation",
+			args:       []string{"delete", "PO2"},
+			input:      "n\n",
+			wantOutput: []string{"Aborted"},
+		},
+		{
+			testName:    "delete mine with --yes",
+			args:        []string{"delete", "--mine", "--since", "2025-01-01", "--until", "2025-02-01", "--yes"},
+			wantDeleted: []string{"PO1"},
+		},
+		{
+			testName:    "delete range",
+			args:        []string{"delete", "--range", "--since", "2025-01-01", "--until", "2025-02-01"},
+			input:       "yes\n",
+			wantDeleted: []string{"PO1", "PO2"},
+		},
+		{
+			testName: "range requires bounds",
+			args:     []string{"delete", "--range", "--since", "2025-01-01"},
+			wantErr:  true,
+		},
+		{
+			testName: "conflicting modes",
+			args:     []string{"delete", "PO1", "--mine"},
+			wantErr:  true,
+		},
+		{
+			testName:    "unknown id",
+			args:        []string{"delete", "--yes", "PO9"},
+			wantErr:     true,
+			wantDeleted: []string{"PO9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			addTestOverrides(fixture.MockClient)
+			fixture.Context.Reader = strings.NewReader(tt.input)
+
+			cmd := NewOverrideCommand(fixture.Context)
+
+			err := cmd.Execute(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OverrideCommand.Execute() error = %v, wantErr %v\n%s", err, tt.wantErr, fixture.GetOutput())
+			}
+
+			if strings.Join(fixture.MockClient.DeleteOverrideCalls, ",") != strings.Join(tt.wantDeleted, ",") {
+				t.Errorf("Expected deletes %v, got %v", tt.wantDeleted, fixture.MockClient.DeleteOverrideCalls)
+			}
+			if !fixture.ContainsOutput(tt.wantOutput...) {
+				t.Errorf("Expected output to contain %v, got:\n%s", tt.wantOutput, fixture.GetOutput())
+			}
+		})
+	}
+}
+
+func TestOverrideCommand_Delete_WrapsFailures(t *testing.T) {
+	fixture := NewTestFixture()
+	addTestOverrides(fixture.MockClient)
+
+	cmd := NewOverrideCommand(fixture.Context)
+	err := cmd.Execute(context.Background(), []string{"delete", "--yes", "PO1", "PO9"})
+	if err == nil || !strings.Contains(err.Error(), "failed to delete 1 of 2 override(s)") {
+		t.Fatalf("Expected a partial failure, got %v", err)
+	}
+	if !pagerduty.IsNotFound(err) || ErrorHint(err) == "" {
+		t.Errorf("Expected the API error to be wrapped, got %v", err)
+	}
+}
@@ -15,9 +15,12 @@
 package commands
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
 )
 
 func TestOverrideCommand_Execute(t *testing.T) {
@@ -31,7 +34,7 @@ func TestOverrideCommand_Execute(t *testing.T) {
 	}{
 		{
 			testName: "successful single override",
-			args:     []string{"--user", "john@example.com", "--target", "jane@example.com", "--start", "2024-03-15 09:00", "--end", "2024-03-15 17:00"},
+			args:     []string{"--user", "john@example.com", "--target", "jane@example.com", "--start", "2024-03-15 09:00", "--end", "2024-03-15 17:00", "--yes"},
 			setupMock: func(mock *MockPagerDutyClient, now time.Time) {
 				mock.AddUser("USER001", "John Doe", "john@example.com")
 				mock.AddUser("USER002", "Jane Smith", "jane@example.com")
@@ -49,6 +52,27 @@ func TestOverrideCommand_Execute(t *testing.T) {
 				"End:",
 			},
 		},
+		{
+			testName: "partial failure lists created overrides",
+			args:     []string{"--user", "john@example.com", "--target", "jane@example.com", "--start", "2024-03-15 00:00", "--end", "2024-03-17 00:00", "--yes"},
+			setupMock: func(mock *MockPagerDutyClient, now time.Time) {
+				mock.AddUser("USER001", "John Doe", "john@example.com")
+				mock.AddUser("USER002", "Jane Smith", "jane@example.com")
+
+				day1 := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+				day2 := day1.AddDate(0, 0, 1)
+				mock.AddOnCall("USER002", "Jane Smith", "jane@example.com", day1, day1.Add(8*time.Hour))
+				mock.AddOnCall("USER002", "Jane Smith", "jane@example.com", day2, day2.Add(8*time.Hour))
+				mock.SetPartialOverrides(1)
+			},
+			wantErr:    true,
+			wantErrMsg: "error creating overrides",
+			wantOutput: []string{
+				"Created 1 of 2 override(s) before the error:",
+				"PO001",
+				"myshift override delete --schedule SCHED123 <id>...",
+			},
+		},
 		{
 			testName: "missing required flags",
 			args:     []string{"--user", "john@example.com"},
@@ -69,7 +93,7 @@ func TestOverrideCommand_Execute(t *testing.T) {
 			cmd := NewOverrideCommand(fixture.Context)
 
 			// Execute
-			err := cmd.Execute(tt.args)
+			err := cmd.Execute(context.Background(), tt.args)
 
 			// Verify error expectation
 			if (err != nil) != tt.wantErr {
@@ -85,8 +109,8 @@ func TestOverrideCommand_Execute(t *testing.T) {
 				}
 			}
 
-			// Verify output if no error expected
-			if !tt.wantErr {
+			// Verify output
+			if len(tt.wantOutput) > 0 {
 				if !fixture.ContainsOutput(tt.wantOutput...) {
 					t.Errorf("Expected output to contain %v, got:\n%s", tt.wantOutput, fixture.GetOutput())
 				}
@@ -95,6 +119,179 @@ func TestOverrideCommand_Execute(t *testing.T) {
 	}
 }
 
+func TestOverrideCommand_Execute_ClipsToWindow(t *testing.T) {
+	tests := []struct {
+		testName  string
+		extraArgs []string
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			testName:  "clipped to requested window",
+			wantStart: time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 1, 15, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:  "whole shifts",
+			extraArgs: []string{"--whole-shifts"},
+			wantStart: time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			fixture.MockClient.AddUser("USER002", "Jane Smith", "jane@example.com")
+
+			// A week-long shift for the target user
+			fixture.MockClient.AddOnCall("USER002", "Jane Smith", "jane@example.com",
+				time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC))
+
+			cmd := NewOverrideCommand(fixture.Context)
+
+			args := append([]string{"--user", "john@example.com", "--target", "jane@example.com",
+				"--start", "2025-01-15 09:00", "--end", "2025-01-15 17:00", "--yes"}, tt.extraArgs...)
+			if err := cmd.Execute(context.Background(), args); err != nil {
+				t.Fatalf("OverrideCommand.Execute() failed: %v", err)
+			}
+
+			created := fixture.MockClient.CreateOverridesCalls
+			if len(created) != 1 {
+				t.Fatalf("Expected 1 override, got %d", len(created))
+			}
+			if !created[0].Start.Equal(tt.wantStart) || !created[0].End.Equal(tt.wantEnd) {
+				t.Errorf("Expected override %s, got %s",
+					FormatTimeRange(tt.wantStart, tt.wantEnd), FormatTimeRange(created[0].Start, created[0].End))
+			}
+			if !fixture.ContainsOutput("Shift: 2025-01-13 09:00 UTC to 2025-01-20 09:00 UTC (Jane Smith)") {
+				t.Errorf("Expected original shift bounds in output, got:\n%s", fixture.GetOutput())
+			}
+		})
+	}
+}
+
+func TestOverrideCommand_Execute_TimeZone(t *testing.T) {
+	fixture := NewTestFixture()
+	fixture.MockClient.AddUser("USER002", "Jane Smith", "jane@example.com")
+
+	// A shift across the start of US daylight saving time on 2024-03-10
+	fixture.MockClient.AddOnCall("USER002", "Jane Smith", "jane@example.com",
+		time.Date(2024, 3, 9, 14, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 11, 13, 0, 0, 0, time.UTC))
+
+	cmd := NewOverrideCommand(fixture.Context)
+	args := []string{"--user", "john@example.com", "--target", "jane@example.com",
+		"--start", "2024-03-09 09:00", "--end", "2024-03-10 09:00", "--tz", "America/New_York", "--yes"}
+	if err := cmd.Execute(context.Background(), args); err != nil {
+		t.Fatalf("OverrideCommand.Execute() failed: %v", err)
+	}
+
+	created := fixture.MockClient.CreateOverridesCalls
+	if len(created) != 1 {
+		t.Fatalf("Expected 1 override, got %d", len(created))
+	}
+
+	// 09:00 EST is 14:00 UTC, but 09:00 EDT the next day is 13:00 UTC
+	wantStart := time.Date(2024, 3, 9, 14, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2024, 3, 10, 13, 0, 0, 0, time.UTC)
+	if !created[0].Start.Equal(wantStart) || !created[0].End.Equal(wantEnd) {
+		t.Errorf("Expected override %s, got %s",
+			FormatTimeRange(wantStart, wantEnd), FormatTimeRange(created[0].Start, created[0].End))
+	}
+	if created[0].TimeZone != "America/New_York" {
+		t.Errorf("Expected override time zone America/New_York, got %q", created[0].TimeZone)
+	}
+	if !fixture.ContainsOutput("Start: 2024-03-09 09:00 EST", "End: 2024-03-10 09:00 EDT") {
+		t.Errorf("Expected times in the requested zone, got:\n%s", fixture.GetOutput())
+	}
+}
+
+func TestOverrideCommand_Execute_Confirmation(t *testing.T) {
+	tests := []struct {
+		testName    string
+		extraArgs   []string
+		input       string
+		wantCreated int
+		wantOutput  []string
+	}{
+		{
+			testName:    "dry run",
+			extraArgs:   []string{"--dry-run"},
+			wantCreated: 0,
+			wantOutput: []string{
+				"Overrides to create on schedule Test Schedule (SCHED123):",
+				"Covered by: John Doe <john@example.com>",
+				"Start: 2025-01-15 09:00 UTC",
+				"Dry run: no overrides were created",
+			},
+		},
+		{
+			testName:    "confirmed",
+			input:       "y\n",
+			wantCreated: 1,
+			wantOutput:  []string{"Create 1 override(s)? [y/N]", "Successfully created 1 override(s)"},
+		},
+		{
+			testName:    "declined",
+			input:       "\n",
+			wantCreated: 0,
+			wantOutput:  []string{"Create 1 override(s)? [y/N]", "Aborted; no overrides were created"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			fixture.MockClient.AddUser("USER002", "Jane Smith", "jane@example.com")
+			fixture.MockClient.AddOnCall("USER002", "Jane Smith", "jane@example.com",
+				time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC))
+			fixture.Context.Reader = strings.NewReader(tt.input)
+
+			cmd := NewOverrideCommand(fixture.Context)
+
+			args := append([]string{"--user", "john@example.com", "--target", "jane@example.com",
+				"--start", "2025-01-15 09:00", "--end", "2025-01-15 17:00"}, tt.extraArgs...)
+			if err := cmd.Execute(context.Background(), args); err != nil {
+				t.Fatalf("OverrideCommand.Execute() failed: %v", err)
+			}
+
+			if len(fixture.MockClient.CreateOverridesCalls) != tt.wantCreated {
+				t.Errorf("Expected %d overrides created, got %d", tt.wantCreated, len(fixture.MockClient.CreateOverridesCalls))
+			}
+			if !fixture.ContainsOutput(tt.wantOutput...) {
+				t.Errorf("Expected output to contain %v, got:\n%s", tt.wantOutput, fixture.GetOutput())
+			}
+		})
+	}
+}
+
+func TestPlanOverrides(t *testing.T) {
+	user := &types.User{ID: "USER001"}
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	shifts := []types.OnCall{
+		{Start: day.Add(-12 * time.Hour), End: day.Add(12 * time.Hour)},
+		{Start: day.Add(12 * time.Hour), End: day.Add(36 * time.Hour)},
+		{Start: day.Add(36 * time.Hour), End: day.Add(60 * time.Hour)},
+	}
+
+	planned := PlanOverrides(shifts, user, day.Add(6*time.Hour), day.Add(18*time.Hour), false, time.UTC)
+	if len(planned) != 2 {
+		t.Fatalf("Expected 2 planned overrides, got %d", len(planned))
+	}
+	if !planned[0].Override.Start.Equal(day.Add(6*time.Hour)) || !planned[0].Override.End.Equal(day.Add(12*time.Hour)) {
+		t.Errorf("Unexpected first override: %s", FormatTimeRange(planned[0].Override.Start, planned[0].Override.End))
+	}
+	if !planned[1].Override.Start.Equal(day.Add(12*time.Hour)) || !planned[1].Override.End.Equal(day.Add(18*time.Hour)) {
+		t.Errorf("Unexpected second override: %s", FormatTimeRange(planned[1].Override.Start, planned[1].Override.End))
+	}
+	if planned[1].Override.User.ID != "USER001" {
+		t.Errorf("Expected override for USER001, got %s", planned[1].Override.User.ID)
+	}
+}
+
 // Simple benchmark to ensure performance is reasonable
 func BenchmarkOverrideCommand_Execute(b *testing.B) {
 	fixture := NewTestFixture()
@@ -107,11 +304,11 @@ func BenchmarkOverrideCommand_Execute(b *testing.B) {
 
 	cmd := NewOverrideCommand(fixture.Context)
 
-	args := []string{"--user", "john@example.com", "--target", "jane@example.com", "--start", "2024-03-15 09:00", "--end", "2024-03-15 17:00"}
+	args := []string{"--user", "john@example.com", "--target", "jane@example.com", "--start", "2024-03-15 09:00", "--end", "2024-03-15 17:00", "--yes"}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		fixture.ClearOutput()
-		_ = cmd.Execute(args)
+		_ = cmd.Execute(context.Background(), args)
 	}
 }
@@ -16,7 +16,11 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"strings"
+
+	"github.com/jdcasey/myshift-go/internal/types"
 )
 
 // PlanCommand handles the "plan" command functionality.
@@ -32,20 +36,36 @@ func NewPlanCommand(ctx *CommandContext) *PlanCommand {
 }
 
 // Execute runs the plan command to show all shifts in a schedule.
-func (p *PlanCommand) Execute(args []string) error {
+func (p *PlanCommand) Execute(ctx context.Context, args []string) error {
 	parser := NewFlagParser("plan").
 		AddDaysFlag(28, "Number of days to show").
-		AddStartFlag("", "Start date (YYYY-MM-DD)").
-		AddEndFlag("", "End date (YYYY-MM-DD)").
-		AddFormatFlag("text", "Output format (text, ical)").
+		AddScheduleFlag("Schedule alias or ID; repeat to combine schedules (uses the default schedule if not provided)").
+		AddStartFlag("", "Start date, e.g. 2025-01-15, today or 'next monday'").
+		AddEndFlag("", "End date, e.g. 2025-01-29, +2w or 'end of month'").
+		AddFormatFlag("text", "Output format (text, ical, json, csv, tsv, markdown, html, template, calendar)").
+		AddColumnsFlag("Columns for csv and tsv output (default: start,end,user,email,schedule,hours)").
+		AddTemplateFlag("Template text, or the name of a template in the config, for template output").
+		AddAlarmFlag("Add a reminder this long before each shift for ical output, e.g. 1h,15m; repeatable").
+		AddOnlyUserFlag("Only show shifts of the user with this email, e.g. for a personal calendar feed").
+		AddTimeZoneFlag("Time zone for dates and times: an IANA name, local, or schedule (default: time_zone from config, else local)").
 		SetUsage(func() {
 			fmt.Print(`Usage: myshift plan [options]
 
 Options:
   --days int         Number of days to show (default: 28)
-  --start string     Start date (YYYY-MM-DD) 
-  --end string       End date (YYYY-MM-DD)
-  --format, -o string  Output format: text, ical (default: text)
+  --schedule string  Schedule alias or ID; repeat to combine schedules
+  --start string     Start date, e.g. 2025-01-15, today or 'next monday'
+  --end string       End date, e.g. 2025-01-29, +2w or 'end of month'
+  --format, -o string  Output format: text, ical, json, csv, tsv, markdown, html, template,
+                       calendar (default: text)
+  --columns string     Columns for csv and tsv output (default: start,end,user,email,schedule,hours)
+  --template string    Template text, or the name of a template in the config, for template output
+  --alarm string       Add a reminder this long before each shift for ical output,
+                       e.g. 1h,15m; repeatable
+  --only-user string   Only show shifts of the user with this email, e.g. for a personal
+                       calendar feed
+  --tz string          Time zone: an IANA name (e.g. Europe/Berlin), local, or schedule
+                       (default: time_zone from config, else local)
 
 `)
 		})
@@ -60,29 +80,54 @@ Options:
 		return nil
 	}
 
-	// Get schedule ID
-	scheduleID, err := p.GetScheduleID()
+	// Resolve schedule aliases to IDs
+	scheduleIDs, err := p.ResolveScheduleIDs(flags.Schedules)
+	if err != nil {
+		return err
+	}
+
+	// Dates are read and shifts shown in the chosen time zone
+	loc, err := p.Location(ctx, flags.TimeZone, scheduleIDs[0])
 	if err != nil {
 		return err
 	}
 
 	// Calculate time range
-	start, end, err := CalculateTimeRange(flags.Start, flags.End, flags.Days)
+	start, end, err := CalculateTimeRange(flags.Start, flags.End, flags.Days, loc)
 	if err != nil {
 		return err
 	}
 
-	// Get all on-call shifts for the schedule
-	onCalls, err := p.GetOnCallsForSchedule(scheduleID, start, end)
+	// Get all on-call shifts for the schedules, merged chronologically,
+	// optionally narrowed to one user
+	var onlyUser *types.User
+	var onCalls []types.OnCall
+	if flags.OnlyUser != "" {
+		onlyUser, err = p.client.FindUserByEmail(ctx, flags.OnlyUser)
+		if err != nil {
+			return fmt.Errorf("error finding user %s: %w", flags.OnlyUser, err)
+		}
+		onCalls, err = p.GetOnCallsForUser(ctx, scheduleIDs, onlyUser.ID, start, end)
+	} else {
+		onCalls, err = p.GetOnCallsForSchedules(ctx, scheduleIDs, start, end)
+	}
 	if err != nil {
 		return err
 	}
 
+	onCalls = ShiftsIn(onCalls, loc)
+
 	// Build user map for display
-	userMap := p.BuildUserMap(onCalls)
+	userMap := p.BuildUserMap(ctx, onCalls)
 
 	// Get the appropriate formatter
-	formatter, err := GetFormatter(flags.Format)
+	options := p.FormatOptions(flags, loc)
+	switch strings.ToLower(flags.Format) {
+	case "ical", "ics":
+		// Only calendars are named, which may need schedule names from PagerDuty
+		options.Calendar = p.calendarName(ctx, scheduleIDs, onCalls, onlyUser)
+	}
+	formatter, err := GetFormatter(flags.Format, options)
 	if err != nil {
 		return err
 	}
@@ -91,15 +136,55 @@ Options:
 	return formatter.Format(p.writer, onCalls, userMap, start, end)
 }
 
+// calendarName names a calendar of the shifts of scheduleIDs, and of user if
+// not nil, e.g. "On-call: Primary (Jane Smith)". Schedule names come from the
+// shifts, or from PagerDuty for schedules without shifts, falling back to IDs.
+func (p *PlanCommand) calendarName(ctx context.Context, scheduleIDs []string, onCalls []types.OnCall, user *types.User) string {
+	names := make(map[string]string)
+	for _, shift := range onCalls {
+		if shift.Schedule.Name != "" {
+			names[shift.Schedule.ID] = shift.Schedule.Name
+		}
+	}
+
+	labels := make([]string, 0, len(scheduleIDs))
+	for _, id := range scheduleIDs {
+		name, ok := names[id]
+		if !ok {
+			name = id
+			if schedule, err := p.client.GetSchedule(ctx, id); err == nil && schedule.Name != "" {
+				name = schedule.Name
+			}
+		}
+		labels = append(labels, name)
+	}
+
+	name := "On-call: " + strings.Join(labels, ", ")
+	if user != nil {
+		name += fmt.Sprintf(" (%s)", user.Name)
+	}
+	return name
+}
+
 // Usage returns the usage information for the plan command
 func (p *PlanCommand) Usage() string {
 	return `Usage: myshift plan [options]
 
 Options:
   --days int         Number of days to show (default: 28)
-  --start string     Start date (YYYY-MM-DD) 
-  --end string       End date (YYYY-MM-DD)
-  --format, -o string  Output format: text, ical (default: text)
+  --schedule string  Schedule alias or ID; repeat to combine schedules
+  --start string     Start date, e.g. 2025-01-15, today or 'next monday'
+  --end string       End date, e.g. 2025-01-29, +2w or 'end of month'
+  --format, -o string  Output format: text, ical, json, csv, tsv, markdown, html, template,
+                       calendar (default: text)
+  --columns string     Columns for csv and tsv output (default: start,end,user,email,schedule,hours)
+  --template string    Template text, or the name of a template in the config, for template output
+  --alarm string       Add a reminder this long before each shift for ical output,
+                       e.g. 1h,15m; repeatable
+  --only-user string   Only show shifts of the user with this email, e.g. for a personal
+                       calendar feed
+  --tz string          Time zone: an IANA name (e.g. Europe/Berlin), local, or schedule
+                       (default: time_zone from config, else local)
 
 `
 }
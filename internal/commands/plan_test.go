@@ -15,6 +15,8 @@
 package commands
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 )
@@ -63,7 +65,7 @@ func TestPlanCommand_Execute(t *testing.T) {
 			cmd := NewPlanCommand(fixture.Context)
 
 			// Execute
-			err := cmd.Execute(tt.args)
+			err := cmd.Execute(context.Background(), tt.args)
 
 			// Verify error expectation
 			if (err != nil) != tt.wantErr {
@@ -81,6 +83,203 @@ func TestPlanCommand_Execute(t *testing.T) {
 	}
 }
 
+func TestPlanCommand_Execute_MultipleSchedules(t *testing.T) {
+	fixture := NewTestFixture()
+	fixture.Config.Schedules = map[string]string{
+		"primary": "PPRIMARY",
+		"db":      "PDB",
+	}
+
+	tomorrow := fixture.Now.Add(24 * time.Hour)
+	fixture.MockClient.AddUser("USER002", "Jane Smith", "jane@example.com")
+	fixture.MockClient.AddOnCallForSchedule("PDB", "Database", "USER002", "Jane Smith", "jane@example.com",
+		tomorrow.Add(time.Hour), tomorrow.Add(9*time.Hour))
+	fixture.MockClient.AddOnCallForSchedule("PPRIMARY", "Primary", "USER001", "John Doe", "john@example.com",
+		tomorrow, tomorrow.Add(8*time.Hour))
+	fixture.MockClient.AddOnCallForSchedule("POTHER", "Other", "USER001", "John Doe", "john@example.com",
+		tomorrow, tomorrow.Add(8*time.Hour))
+
+	cmd := NewPlanCommand(fixture.Context)
+
+	err := cmd.Execute(context.Background(), []string{"--days", "7", "--schedule", "primary", "--schedule", "db"})
+	if err != nil {
+		t.Fatalf("PlanCommand.Execute() failed: %v", err)
+	}
+
+	output := fixture.GetOutput()
+	if !fixture.ContainsOutput("John Doe [Primary]", "Jane Smith [Database]") {
+		t.Errorf("Expected schedule names in output, got:\n%s", output)
+	}
+	if strings.Contains(output, "[Other]") {
+		t.Errorf("Expected unselected schedule to be excluded, got:\n%s", output)
+	}
+	if strings.Index(output, "[Primary]") > strings.Index(output, "[Database]") {
+		t.Errorf("Expected shifts to be sorted by start time, got:\n%s", output)
+	}
+
+	scheduleIDs := fixture.MockClient.GetOnCallsCalls[0]["schedule_ids[]"]
+	if len(scheduleIDs) != 2 || scheduleIDs[0] != "PPRIMARY" || scheduleIDs[1] != "PDB" {
+		t.Errorf("Expected both schedules to be queried, got %v", scheduleIDs)
+	}
+}
+
+func TestPlanCommand_Execute_Template(t *testing.T) {
+	tests := []struct {
+		testName   string
+		args       []string
+		wantErr    bool
+		wantOutput string
+	}{
+		{
+			testName:   "named template from config",
+			args:       []string{"--format", "template", "--template", "names"},
+			wantOutput: "John Doe;",
+		},
+		{
+			testName:   "inline template",
+			args:       []string{"--format", "template", "--template", "{{len .Shifts}} shift(s)"},
+			wantOutput: "1 shift(s)",
+		},
+		{
+			testName: "template format without template",
+			args:     []string{"--format", "template"},
+			wantErr:  true,
+		},
+		{
+			testName: "template with another format",
+			args:     []string{"--format", "text", "--template", "names"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			fixture.Config.Templates = map[string]string{
+				"names": "{{range .Shifts}}{{userName .}};{{end}}",
+			}
+			tomorrow := fixture.Now.Add(24 * time.Hour)
+			fixture.MockClient.AddOnCall("USER001", "John Doe", "john@example.com",
+				tomorrow, tomorrow.Add(8*time.Hour))
+
+			cmd := NewPlanCommand(fixture.Context)
+			err := cmd.Execute(context.Background(), append([]string{"--days", "7"}, tt.args...))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanCommand.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fixture.GetOutput() != tt.wantOutput {
+				t.Errorf("Expected output %q, got %q", tt.wantOutput, fixture.GetOutput())
+			}
+		})
+	}
+}
+
+func TestPlanCommand_Execute_TimeZone(t *testing.T) {
+	tests := []struct {
+		testName   string
+		args       []string
+		configTZ   string
+		wantOutput []string
+	}{
+		{
+			testName:   "tz flag",
+			args:       []string{"--tz", "Asia/Kolkata"},
+			wantOutput: []string{"2024-06-03 14:30 IST to 2024-06-03 22:30 IST: John Doe"},
+		},
+		{
+			testName:   "config time zone",
+			configTZ:   "America/New_York",
+			wantOutput: []string{"2024-06-03 05:00 EDT to 2024-06-03 13:00 EDT: John Doe"},
+		},
+		{
+			testName:   "schedule time zone",
+			args:       []string{"--tz", "schedule"},
+			wantOutput: []string{"2024-06-03 11:00 CEST to 2024-06-03 19:00 CEST: John Doe"},
+		},
+		{
+			testName:   "csv header names the zone",
+			args:       []string{"--tz", "Asia/Kolkata", "--format", "csv", "--columns", "start,end"},
+			wantOutput: []string{"start (Asia/Kolkata),end (Asia/Kolkata)", "2024-06-03 14:30:00,2024-06-03 22:30:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			fixture.Config.TimeZone = tt.configTZ
+			fixture.MockClient.AddSchedule("SCHED123", "Test Schedule", "Europe/Amsterdam")
+			fixture.MockClient.AddOnCall("USER001", "John Doe", "john@example.com",
+				time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC), time.Date(2024, 6, 3, 17, 0, 0, 0, time.UTC))
+
+			cmd := NewPlanCommand(fixture.Context)
+			args := append([]string{"--start", "2024-06-01", "--end", "2024-06-08"}, tt.args...)
+			if err := cmd.Execute(context.Background(), args); err != nil {
+				t.Fatalf("PlanCommand.Execute() failed: %v", err)
+			}
+			if !fixture.ContainsOutput(tt.wantOutput...) {
+				t.Errorf("Expected output to contain %v, got:\n%s", tt.wantOutput, fixture.GetOutput())
+			}
+		})
+	}
+}
+
+func TestPlanCommand_Execute_OnlyUser(t *testing.T) {
+	fixture := NewTestFixture()
+	fixture.MockClient.AddUser("USER002", "Jane Smith", "jane@example.com")
+	fixture.MockClient.AddOnCall("USER001", "John Doe", "john@example.com",
+		time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC), time.Date(2024, 6, 4, 9, 0, 0, 0, time.UTC))
+	fixture.MockClient.AddOnCall("USER002", "Jane Smith", "jane@example.com",
+		time.Date(2024, 6, 4, 9, 0, 0, 0, time.UTC), time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC))
+
+	cmd := NewPlanCommand(fixture.Context)
+	args := []string{"--start", "2024-06-01", "--end", "2024-06-08", "--format", "ical",
+		"--only-user", "jane@example.com", "--alarm", "1h,15m"}
+	if err := cmd.Execute(context.Background(), args); err != nil {
+		t.Fatalf("PlanCommand.Execute() failed: %v", err)
+	}
+
+	output := fixture.GetOutput()
+	if strings.Count(output, "BEGIN:VEVENT") != 1 || !strings.Contains(output, "UID:oncall-SCHED123-USER002-") {
+		t.Errorf("Expected only Jane's shift, got:\n%s", output)
+	}
+	if strings.Count(output, "BEGIN:VALARM") != 2 {
+		t.Errorf("Expected two alarms, got:\n%s", output)
+	}
+	if !strings.Contains(output, "X-WR-CALNAME:On-call: Test Schedule (Jane Smith)") {
+		t.Errorf("Expected the calendar to be named after the schedule and user, got:\n%s", output)
+	}
+}
+
+func TestPlanCommand_Execute_CalendarName(t *testing.T) {
+	tests := []struct {
+		format        string
+		wantSchedules int
+	}{
+		{"text", 0},
+		{"json", 0},
+		{"ical", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			// Without shifts, only a calendar needs the schedule's name from PagerDuty
+			fixture := NewTestFixture()
+			fixture.MockClient.AddSchedule("SCHED123", "Test Schedule", "UTC")
+
+			cmd := NewPlanCommand(fixture.Context)
+			args := []string{"--start", "2024-06-01", "--end", "2024-06-08", "--format", tt.format}
+			if err := cmd.Execute(context.Background(), args); err != nil {
+				t.Fatalf("PlanCommand.Execute() failed: %v", err)
+			}
+
+			if got := len(fixture.MockClient.GetScheduleCalls); got != tt.wantSchedules {
+				t.Errorf("GetSchedule called %d times, want %d", got, tt.wantSchedules)
+			}
+		})
+	}
+}
+
 func BenchmarkPlanCommand_Execute(b *testing.B) {
 	fixture := NewTestFixture()
 	cmd := NewPlanCommand(fixture.Context)
@@ -88,6 +287,6 @@ func BenchmarkPlanCommand_Execute(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		fixture.ClearOutput()
-		_ = cmd.Execute([]string{"--days", "7"})
+		_ = cmd.Execute(context.Background(), []string{"--days", "7"})
 	}
 }
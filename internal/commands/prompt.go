@@ -0,0 +1,88 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Prompt writes a question to the command's writer and reads a single line of
+// response from its reader. The response is returned with surrounding
+// whitespace removed. io.EOF is returned if the input ends before any answer.
+func (b *BaseCommand) Prompt(question string) (string, error) {
+	fmt.Fprint(b.writer, question)
+	line, err := readLine(b.reader)
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// Confirm asks a yes/no question, defaulting to "no". End of input is treated
+// as "no" so that non-interactive runs never proceed by accident.
+func (b *BaseCommand) Confirm(question string) (bool, error) {
+	answer, err := b.Prompt(question + " [y/N] ")
+	if err == io.EOF {
+		fmt.Fprintln(b.writer)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// CanPrompt reports whether the command's input can be used to ask the user
+// questions. Regular files and pipes are not considered interactive, so scripts
+// that redirect stdin never block on a prompt.
+func (b *BaseCommand) CanPrompt() bool {
+	file, ok := b.reader.(*os.File)
+	if !ok {
+		return b.reader != nil
+	}
+	return isTerminal(file)
+}
+
+// readLine reads a single line from r without buffering past the newline, so
+// that a shared input stream can be used for several prompts in turn.
+func readLine(r io.Reader) (string, error) {
+	if r == nil {
+		return "", io.EOF
+	}
+
+	var sb strings.Builder
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return strings.TrimSuffix(sb.String(), "\r"), nil
+			}
+			sb.WriteByte(buf[0])
+		}
+		if err != nil {
+			return sb.String(), err
+		}
+	}
+}
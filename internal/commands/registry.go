@@ -15,6 +15,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -31,21 +32,25 @@ func NewCommandRegistry(ctx *CommandContext) *CommandRegistry {
 
 	// Register all commands
 	registry.commands["next"] = NewNextCommand(ctx)
+	registry.commands["now"] = NewNowCommand(ctx)
 	registry.commands["plan"] = NewPlanCommand(ctx)
 	registry.commands["upcoming"] = NewUpcomingCommand(ctx)
 	registry.commands["override"] = NewOverrideCommand(ctx)
+	registry.commands["schedules"] = NewSchedulesCommand(ctx)
+	registry.commands["swap"] = NewSwapCommand(ctx)
+	registry.commands["serve"] = NewServeCommand(ctx)
 	// Note: REPL is not included in the registry to avoid circular dependency
 
 	return registry
 }
 
 // Execute executes a command by name
-func (r *CommandRegistry) Execute(cmdName string, args []string) error {
+func (r *CommandRegistry) Execute(ctx context.Context, cmdName string, args []string) error {
 	cmd, exists := r.commands[cmdName]
 	if !exists {
 		return fmt.Errorf("unknown command: %s", cmdName)
 	}
-	return cmd.Execute(args)
+	return cmd.Execute(ctx, args)
 }
 
 // GetCommand returns a command by name
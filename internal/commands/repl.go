@@ -17,69 +17,218 @@ package commands
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"strings"
+	"os/signal"
+
+	"github.com/jdcasey/myshift-go/internal/config"
+	"github.com/jdcasey/myshift-go/internal/types"
 )
 
 // ReplCommand handles the "repl" command functionality.
 type ReplCommand struct {
 	*BaseCommand
+	reader      io.Reader
+	session     *sessionClient
+	defaults    *types.Config // The loaded config, which session settings start from
+	registry    *CommandRegistry
+	historyPath func() (string, error)
 }
 
-// NewReplCommand creates a new ReplCommand instance.
+// NewReplCommand creates a new ReplCommand instance. Commands run from the
+// REPL share a client that caches users and schedules for the session, and a
+// copy of the config that the use, set and unset commands change.
 func NewReplCommand(ctx *CommandContext) *ReplCommand {
-	return &ReplCommand{
-		BaseCommand: NewBaseCommand(ctx.Client, ctx.Config, ctx.Writer),
+	defaults := ctx.Config
+	if defaults == nil {
+		defaults = &types.Config{}
+	}
+	settings := *defaults
+	session := newSessionClient(ctx.Client, defaults.MyUser)
+
+	r := &ReplCommand{
+		BaseCommand: NewBaseCommand(session, &settings, ctx.Writer),
+		reader:      os.Stdin,
+		session:     session,
+		defaults:    defaults,
+		historyPath: config.HistoryPath,
 	}
+	r.registry = r.newRegistry(ctx.Reader)
+	return r
+}
+
+// newRegistry creates the registry that runs commands for the session.
+// Commands prompt for input from input.
+func (r *ReplCommand) newRegistry(input io.Reader) *CommandRegistry {
+	cmdCtx := NewCommandContext(r.client, r.config, r.writer)
+	cmdCtx.Reader = input
+	return NewCommandRegistry(cmdCtx)
 }
 
 // Execute runs the REPL (Read-Eval-Print Loop) for interactive commands.
-func (r *ReplCommand) Execute(args []string) error {
+//
+// Input lines are split into arguments like a shell would, so quoted values
+// may contain spaces. On a terminal that supports raw mode, lines are read
+// with a line editor that keeps history and completes commands, flags,
+// schedule aliases and emails; elsewhere whole lines are read as typed.
+//
+// The REPL installs its own SIGINT handler: Ctrl+C while a command is running
+// cancels only that command, and Ctrl+C at the prompt is ignored. The REPL
+// exits on 'quit', 'exit', end of input, or when ctx is cancelled.
+func (r *ReplCommand) Execute(ctx context.Context, args []string) error {
 	fmt.Fprintln(r.writer, "Welcome to MyShift REPL. Type 'help' or '?' to list commands.")
-	fmt.Fprintln(r.writer, "Type 'quit' or 'exit' to quit, or use Ctrl+C.")
+	fmt.Fprintln(r.writer, "Type 'quit' or 'exit' to quit. Ctrl+C cancels the running command.")
 
-	scanner := bufio.NewScanner(os.Stdin)
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	if terminal, ok := r.reader.(*os.File); ok && rawModeSupported && isTerminal(terminal) && isTerminalWriter(r.writer) {
+		return r.runEditor(ctx, interrupts, terminal)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	lines, readErr := r.readLines(stop)
+	r.registry = r.newRegistry(&lineChannelReader{lines: lines})
 
 	for {
-		fmt.Print("(myshift) ")
+		fmt.Print(r.prompt())
 
-		if !scanner.Scan() {
+		var line string
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-interrupts:
+			fmt.Fprintln(r.writer, "\nType 'quit' or 'exit' to leave the REPL.")
+			continue
+		case line, ok = <-lines:
+		}
+		if !ok {
 			// EOF or error
 			break
 		}
 
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
+		if !r.runLine(ctx, interrupts, line) {
+			return nil
 		}
+	}
+
+	if err := <-readErr; err != nil {
+		return fmt.Errorf("error reading input: %w", err)
+	}
+
+	return nil
+}
 
-		args := strings.Fields(line)
-		if len(args) == 0 {
+// runEditor runs the REPL on a terminal, reading each line with a line
+// editor. The terminal is in raw mode only while a line is being edited, so
+// Ctrl+C at the prompt discards the line while Ctrl+C during a command still
+// cancels it, and commands prompt for input as usual. Commands read their
+// prompts through the editor, so answers typed ahead are not lost.
+func (r *ReplCommand) runEditor(ctx context.Context, interrupts <-chan os.Signal, terminal *os.File) error {
+	history := &history{}
+	if path, err := r.historyPath(); err != nil {
+		fmt.Fprintf(r.writer, "Warning: history will not be saved: %v\n", err)
+	} else if history, err = loadHistory(path); err != nil {
+		fmt.Fprintf(r.writer, "Warning: %v\n", err)
+	}
+	editor := newLineEditor(terminal, r.writer, history, r.complete)
+	r.registry = r.newRegistry(editor.Input())
+
+	for ctx.Err() == nil {
+		restore, err := makeRaw(terminal)
+		if err != nil {
+			return fmt.Errorf("error configuring terminal: %w", err)
+		}
+		line, err := editor.ReadLine(r.prompt())
+		if restoreErr := restore(); restoreErr != nil {
+			return fmt.Errorf("error restoring terminal: %w", restoreErr)
+		}
+
+		switch {
+		case errors.Is(err, errInterrupted):
+			fmt.Fprintln(r.writer, "Type 'quit' or 'exit' to leave the REPL.")
 			continue
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return fmt.Errorf("error reading input: %w", err)
 		}
 
-		command := args[0]
-		commandArgs := args[1:]
+		if err := history.add(line); err != nil {
+			// Keep the history for this session only
+			fmt.Fprintf(r.writer, "Warning: %v\n", err)
+			history.path = ""
+		}
 
-		switch command {
-		case "help", "?":
-			r.printHelp()
-		case "quit", "exit":
-			fmt.Fprintln(r.writer, "Goodbye!")
+		if !r.runLine(ctx, interrupts, line) {
 			return nil
-		case "next", "plan", "upcoming", "override":
-			r.handleCommand(command, commandArgs)
-		default:
-			fmt.Fprintf(r.writer, "Unknown command: %s. Type 'help' for available commands.\n", command)
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading input: %w", err)
+	return nil
+}
+
+// runLine splits a line of input into arguments and runs it. It returns false
+// when the REPL should exit.
+func (r *ReplCommand) runLine(ctx context.Context, interrupts <-chan os.Signal, line string) bool {
+	args, err := splitArgs(line)
+	if err != nil {
+		fmt.Fprintf(r.writer, "Error: %v\n", err)
+		return true
+	}
+	if len(args) == 0 {
+		return true
 	}
 
-	return nil
+	command := args[0]
+	commandArgs := args[1:]
+
+	switch {
+	case command == "help" || command == "?":
+		r.printHelp()
+	case command == "quit" || command == "exit":
+		fmt.Fprintln(r.writer, "Goodbye!")
+		return false
+	case command == "use" || command == "set" || command == "unset":
+		if err := r.changeSettings(ctx, command, commandArgs); err != nil {
+			fmt.Fprintf(r.writer, "Error: %v\n", err)
+		}
+	case isReplCommand(command):
+		r.handleCommand(ctx, interrupts, command, commandArgs)
+	default:
+		fmt.Fprintf(r.writer, "Unknown command: %s. Type 'help' for available commands.\n", command)
+	}
+	return true
+}
+
+// readLines reads input lines in the background so that the REPL can react
+// to interrupts while waiting at the prompt. The lines channel is closed at
+// end of input, after which the scanner error (if any) is sent on the error
+// channel. Reading stops early when stop is closed.
+func (r *ReplCommand) readLines(stop <-chan struct{}) (<-chan string, <-chan error) {
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r.reader)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-stop:
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	return lines, readErr
 }
 
 func (r *ReplCommand) printHelp() {
@@ -91,9 +240,18 @@ func (r *ReplCommand) printHelp() {
 	fmt.Fprintf(r.writer, `Available commands:
 
   next [--user email] [--days N]  Show the next on-call shift for a user%s
-  plan [--days N]                 Show planned shifts (default: 28 days)
+  now [--schedule S] [--escalation-policy P]  Show who is on call right now
+  plan [--days N] [--schedule S]  Show planned shifts (default: 28 days)
   upcoming [--user email] [--days N]  Show upcoming shifts for a user%s (default: 28 days)
-  override --user U --target T --start S --end E  Create an override
+  override --user U --target T --start S --end E  Create overrides (shows a preview, asks for confirmation)
+  override list [--user U]        List overrides on the schedule
+  override delete <id>|--mine|--range  Delete overrides (asks for confirmation)
+  swap --with E --mine D --theirs D  Trade shifts with another user
+  schedules [--query Q]           List schedules and pick a default
+  use schedule S                  Use schedule S when later commands have no --schedule
+  set schedule|tz|user VALUE      Change a default for the rest of the session
+  set                             Show the session's defaults
+  unset schedule|tz|user          Go back to the default from the config
   help, ?                         Show this help message
   quit, exit                      Exit the REPL
 
@@ -101,23 +259,73 @@ Examples:
   next
   next --user user@example.com
   plan --days 14
+  plan --schedule primary --schedule db
   upcoming --days 7
   upcoming --user user@example.com --days 7
+  use schedule db
+  set tz Europe/Berlin
   override --user user@example.com --target target@example.com --start "2024-03-20 09:00" --end "2024-03-20 17:00"
 
+Quote arguments that contain spaces. On a terminal, Up and Down browse the
+history, Ctrl+R searches it, and Tab completes commands, flags, schedule
+aliases and emails.
+
 `, myUserInfo, myUserInfo)
 }
 
-func (r *ReplCommand) handleCommand(command string, args []string) {
-	// Create a registry for executing commands
-	ctx := NewCommandContext(r.client, r.config, r.writer)
-	registry := NewCommandRegistry(ctx)
+// handleCommand runs a single command with its own cancellable context.
+// An interrupt received while the command runs cancels that context only.
+//
+// Prompts issued by the command read from the REPL's own line stream when a
+// background reader owns stdin, or from the terminal itself.
+func (r *ReplCommand) handleCommand(ctx context.Context, interrupts <-chan os.Signal, command string, args []string) {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	if err := registry.Execute(command, args); err != nil {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-interrupts:
+			cancel()
+		case <-done:
+		}
+	}()
+
+	if err := r.registry.Execute(runCtx, command, args); err != nil {
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintln(r.writer, "Command cancelled")
+			return
+		}
 		fmt.Fprintf(r.writer, "Error: %v\n", err)
+		if hint := ErrorHint(err); hint != "" {
+			fmt.Fprintf(r.writer, "Hint: %s\n", hint)
+		}
 	}
 }
 
+// lineChannelReader adapts the REPL's line channel to an io.Reader so that
+// commands can prompt for input while the REPL is running.
+type lineChannelReader struct {
+	lines   <-chan string
+	pending []byte
+}
+
+// Read implements io.Reader, returning one line (with its newline) at a time.
+func (l *lineChannelReader) Read(p []byte) (int, error) {
+	if len(l.pending) == 0 {
+		line, ok := <-l.lines
+		if !ok {
+			return 0, io.EOF
+		}
+		l.pending = []byte(line + "\n")
+	}
+
+	n := copy(p, l.pending)
+	l.pending = l.pending[n:]
+	return n, nil
+}
+
 // Usage returns the usage information for the repl command
 func (r *ReplCommand) Usage() string {
 	return `Usage: myshift repl
@@ -0,0 +1,128 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// replCommands are the commands the REPL runs through the registry.
+var replCommands = []string{"next", "now", "plan", "upcoming", "override", "swap", "schedules"}
+
+// replSubcommands lists the subcommands completed after a command name.
+var replSubcommands = map[string][]string{
+	"override": {"list", "delete"},
+	"use":      {"schedule"},
+	"set":      replSettings,
+	"unset":    replSettings,
+}
+
+// emailFlags are the flags whose value is completed with known user emails.
+var emailFlags = map[string]bool{
+	"--user":      true,
+	"--target":    true,
+	"--with":      true,
+	"--only-user": true,
+}
+
+// usageFlagPattern finds the flags a command's usage text mentions.
+var usageFlagPattern = regexp.MustCompile(`--[a-z][a-z-]*`)
+
+// complete returns the completions for the argument at the end of line, and
+// where that argument starts. The first argument completes to a command; after
+// a command come its subcommands or, for an argument starting with "-", its
+// flags. Schedules, given with --schedule or to use and set, complete to
+// schedule aliases, and emails complete to the users seen so far.
+func (r *ReplCommand) complete(line string) ([]string, int) {
+	start := strings.LastIndexAny(line, " \t") + 1
+	word := line[start:]
+
+	// Nothing is completed inside quotes
+	args, err := splitArgs(line[:start])
+	if err != nil || strings.ContainsAny(word, `"'\`) {
+		return nil, start
+	}
+
+	var options []string
+	switch {
+	case len(args) == 0:
+		options = append(append(options, replCommands...), "help", "quit", "exit", "use", "set", "unset")
+	case args[len(args)-1] == "--schedule":
+		options = sortedAliases(r.config)
+	case emailFlags[args[len(args)-1]]:
+		options = r.session.Emails()
+	case len(args) == 2 && (args[0] == "use" || args[0] == "set") && args[1] == "schedule":
+		options = sortedAliases(r.config)
+	case len(args) == 2 && args[0] == "set" && args[1] == "user":
+		options = r.session.Emails()
+	case strings.HasPrefix(word, "-"):
+		options = r.commandFlags(args[0])
+	case len(args) == 1:
+		options = replSubcommands[args[0]]
+	}
+
+	var candidates []string
+	for _, option := range options {
+		if strings.HasPrefix(option, word) {
+			candidates = append(candidates, option)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates, start
+}
+
+// commandFlags returns the flags listed in the usage of command.
+func (r *ReplCommand) commandFlags(command string) []string {
+	if !isReplCommand(command) {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var flags []string
+	for _, flag := range usageFlagPattern.FindAllString(r.registry.GetUsage(command), -1) {
+		if !seen[flag] {
+			seen[flag] = true
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// isReplCommand reports whether command is run through the registry.
+func isReplCommand(command string) bool {
+	for _, name := range replCommands {
+		if name == command {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedAliases returns the schedule aliases in config, in order.
+func sortedAliases(config *types.Config) []string {
+	if config == nil {
+		return nil
+	}
+	aliases := make([]string, 0, len(config.Schedules))
+	for alias := range config.Schedules {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
@@ -0,0 +1,279 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jdcasey/myshift-go/internal/pagerduty"
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// sessionClient wraps a PagerDuty client for the length of a REPL session.
+// Users and schedules are cached once fetched, so commands looking up the
+// same users again, as BuildUserMap does, don't repeat the requests. It also
+// remembers the email of every user it sees, for completion. Errors are not
+// cached.
+type sessionClient struct {
+	pagerduty.PagerDutyClient
+
+	mu            sync.Mutex
+	usersByID     map[string]*types.User
+	usersByEmail  map[string]*types.User
+	schedulesByID map[string]*types.Schedule
+	emails        map[string]bool
+}
+
+// newSessionClient wraps client, starting with the given emails known.
+func newSessionClient(client pagerduty.PagerDutyClient, emails ...string) *sessionClient {
+	s := &sessionClient{
+		PagerDutyClient: client,
+		usersByID:       make(map[string]*types.User),
+		usersByEmail:    make(map[string]*types.User),
+		schedulesByID:   make(map[string]*types.Schedule),
+		emails:          make(map[string]bool),
+	}
+	for _, email := range emails {
+		s.addEmail(email)
+	}
+	return s
+}
+
+// addEmail remembers email, ignoring values that are not email addresses.
+func (s *sessionClient) addEmail(email string) {
+	if !strings.Contains(email, "@") {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emails[email] = true
+}
+
+// addUser caches user under both its ID and its email.
+func (s *sessionClient) addUser(user *types.User) {
+	s.addEmail(user.Email)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usersByID[user.ID] = user
+	if user.Email != "" {
+		s.usersByEmail[strings.ToLower(user.Email)] = user
+	}
+}
+
+// Emails returns the emails seen so far, in order.
+func (s *sessionClient) Emails() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	emails := make([]string, 0, len(s.emails))
+	for email := range s.emails {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+	return emails
+}
+
+// FindUserByEmail implements PagerDutyClient, answering from the cache when
+// the user has been seen before.
+func (s *sessionClient) FindUserByEmail(ctx context.Context, email string) (*types.User, error) {
+	s.mu.Lock()
+	user, ok := s.usersByEmail[strings.ToLower(email)]
+	s.mu.Unlock()
+	if ok {
+		return user, nil
+	}
+
+	user, err := s.PagerDutyClient.FindUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	s.addUser(user)
+	return user, nil
+}
+
+// GetUser implements PagerDutyClient, answering from the cache when the user
+// has been seen before.
+func (s *sessionClient) GetUser(ctx context.Context, userID string) (*types.User, error) {
+	s.mu.Lock()
+	user, ok := s.usersByID[userID]
+	s.mu.Unlock()
+	if ok {
+		return user, nil
+	}
+
+	user, err := s.PagerDutyClient.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.addUser(user)
+	return user, nil
+}
+
+// GetSchedule implements PagerDutyClient, answering from the cache when the
+// schedule has been fetched before.
+func (s *sessionClient) GetSchedule(ctx context.Context, scheduleID string) (*types.Schedule, error) {
+	s.mu.Lock()
+	schedule, ok := s.schedulesByID[scheduleID]
+	s.mu.Unlock()
+	if ok {
+		return schedule, nil
+	}
+
+	schedule, err := s.PagerDutyClient.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.schedulesByID[scheduleID] = schedule
+	s.mu.Unlock()
+	return schedule, nil
+}
+
+// GetOnCalls implements PagerDutyClient. Shifts are not cached, since they
+// change, but the emails of the users on call are remembered.
+func (s *sessionClient) GetOnCalls(ctx context.Context, params url.Values) ([]types.OnCall, error) {
+	onCalls, err := s.PagerDutyClient.GetOnCalls(ctx, params)
+	for _, onCall := range onCalls {
+		s.addEmail(onCall.User.Email)
+	}
+	return onCalls, err
+}
+
+// replSettings are the defaults the REPL can change for the rest of a
+// session, by the names the set and unset commands use.
+var replSettings = []string{"schedule", "tz", "user"}
+
+// changeSettings runs the use, set and unset commands, which change the
+// session's copy of the config so that later commands inherit the change.
+func (r *ReplCommand) changeSettings(ctx context.Context, command string, args []string) error {
+	switch {
+	case command == "set" && len(args) == 0:
+		r.showSettings()
+		return nil
+	case command == "use" && (len(args) != 2 || args[0] != "schedule"):
+		return fmt.Errorf("usage: use schedule <alias or ID>")
+	case command == "set" && len(args) != 2:
+		return fmt.Errorf("usage: set schedule|tz|user <value>")
+	case command == "unset" && len(args) != 1:
+		return fmt.Errorf("usage: unset schedule|tz|user")
+	}
+
+	if command == "unset" {
+		return r.unsetSetting(args[0])
+	}
+	return r.setSetting(ctx, args[0], args[1])
+}
+
+// setSetting changes a session default, after checking that the schedule or
+// user exists or that the time zone is known.
+func (r *ReplCommand) setSetting(ctx context.Context, name, value string) error {
+	switch name {
+	case "schedule":
+		scheduleID, err := r.ResolveScheduleID(value)
+		if err != nil {
+			return err
+		}
+		schedule, err := r.client.GetSchedule(ctx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("error finding schedule %s: %w", value, err)
+		}
+		r.config.DefaultSchedule = value
+		fmt.Fprintf(r.writer, "Using schedule %s (%s)\n", schedule.Name, scheduleID)
+	case "tz":
+		if value != ScheduleTimeZone {
+			if _, err := LoadTimeZone(value); err != nil {
+				return err
+			}
+		}
+		r.config.TimeZone = value
+		fmt.Fprintf(r.writer, "Using time zone %s\n", value)
+	case "user":
+		user, err := r.client.FindUserByEmail(ctx, value)
+		if err != nil {
+			return fmt.Errorf("error finding user %s: %w", value, err)
+		}
+		r.config.MyUser = value
+		fmt.Fprintf(r.writer, "Using user %s <%s>\n", user.Name, user.Email)
+	default:
+		return fmt.Errorf("unknown setting: %s (expected schedule, tz or user)", name)
+	}
+	return nil
+}
+
+// unsetSetting restores a session default to its value in the config.
+func (r *ReplCommand) unsetSetting(name string) error {
+	switch name {
+	case "schedule":
+		r.config.DefaultSchedule = r.defaults.DefaultSchedule
+	case "tz":
+		r.config.TimeZone = r.defaults.TimeZone
+	case "user":
+		r.config.MyUser = r.defaults.MyUser
+	default:
+		return fmt.Errorf("unknown setting: %s (expected schedule, tz or user)", name)
+	}
+
+	value := settingValue(r.config, name)
+	if value == "" {
+		value = "not set"
+	}
+	fmt.Fprintf(r.writer, "Using %s from config (%s)\n", name, value)
+	return nil
+}
+
+// showSettings lists the session defaults and where each comes from.
+func (r *ReplCommand) showSettings() {
+	fmt.Fprintln(r.writer, "Session defaults:")
+	for _, name := range replSettings {
+		value := settingValue(r.config, name)
+		switch {
+		case value == "":
+			fmt.Fprintf(r.writer, "  %-9s (not set)\n", name)
+		case value != settingValue(r.defaults, name):
+			fmt.Fprintf(r.writer, "  %-9s %s (set in this session)\n", name, value)
+		default:
+			fmt.Fprintf(r.writer, "  %-9s %s (from config)\n", name, value)
+		}
+	}
+}
+
+// settingValue returns the value of a session setting in config.
+func settingValue(config *types.Config, name string) string {
+	switch name {
+	case "schedule":
+		if config.DefaultSchedule != "" {
+			return config.DefaultSchedule
+		}
+		return config.ScheduleID
+	case "tz":
+		return config.TimeZone
+	case "user":
+		return config.MyUser
+	}
+	return ""
+}
+
+// prompt returns the REPL prompt, which names the schedule in use when it
+// was changed for the session.
+func (r *ReplCommand) prompt() string {
+	if schedule := r.config.DefaultSchedule; schedule != "" && schedule != r.defaults.DefaultSchedule {
+		return fmt.Sprintf("(myshift %s) ", schedule)
+	}
+	return "(myshift) "
+}
@@ -15,7 +15,10 @@
 package commands
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestReplCommand_Creation(t *testing.T) {
@@ -38,3 +41,238 @@ func TestReplCommand_Usage(t *testing.T) {
 		t.Error("Expected usage string to be non-empty")
 	}
 }
+
+func TestReplCommand_Execute(t *testing.T) {
+	fixture := NewTestFixture()
+	replCmd := NewReplCommand(fixture.Context)
+	replCmd.reader = strings.NewReader("help\nbogus\nquit\nnext\n")
+
+	if err := replCmd.Execute(context.Background(), nil); err != nil {
+		t.Fatalf("ReplCommand.Execute() failed: %v", err)
+	}
+
+	if !fixture.ContainsOutput("Available commands:", "Unknown command: bogus", "Goodbye!") {
+		t.Errorf("Unexpected REPL output:\n%s", fixture.GetOutput())
+	}
+	if len(fixture.MockClient.GetOnCallsCalls) != 0 {
+		t.Error("Expected commands after 'quit' to be ignored")
+	}
+}
+
+func TestReplCommand_Execute_CancelledContext(t *testing.T) {
+	fixture := NewTestFixture()
+	replCmd := NewReplCommand(fixture.Context)
+	replCmd.reader = strings.NewReader("")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := replCmd.Execute(ctx, nil); err != nil {
+		t.Fatalf("ReplCommand.Execute() failed: %v", err)
+	}
+}
+
+func TestReplCommand_Execute_QuotedArgs(t *testing.T) {
+	fixture := NewTestFixture()
+	replCmd := NewReplCommand(fixture.Context)
+	replCmd.reader = strings.NewReader(`plan --start "2025-03-03 09:00" --end '2025-03-04 09:00'` + "\nnext --user \"unterminated\nquit\n")
+
+	if err := replCmd.Execute(context.Background(), nil); err != nil {
+		t.Fatalf("ReplCommand.Execute() failed: %v", err)
+	}
+
+	calls := fixture.MockClient.GetOnCallsCalls
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 GetOnCalls call, got %d\n%s", len(calls), fixture.GetOutput())
+	}
+	wantSince := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	if since := calls[0].Get("since"); since != wantSince {
+		t.Errorf("since = %q, want %q", since, wantSince)
+	}
+	if !fixture.ContainsOutput("Error: unterminated double quote") {
+		t.Errorf("expected a quoting error, got:\n%s", fixture.GetOutput())
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"  next  ", []string{"next"}, false},
+		{"next --days\t7", []string{"next", "--days", "7"}, false},
+		{`override --start "2024-03-20 09:00"`, []string{"override", "--start", "2024-03-20 09:00"}, false},
+		{`override --start '2024-03-20 09:00'`, []string{"override", "--start", "2024-03-20 09:00"}, false},
+		{`--start=tomorrow\ 09:00`, []string{"--start=tomorrow 09:00"}, false},
+		{`--start="next monday"`, []string{"--start=next monday"}, false},
+		{`'it''s' "a \"b\" \\ \$c \d"`, []string{"its", `a "b" \ $c \d`}, false},
+		{`'don\t' ""`, []string{`don\t`, ""}, false},
+		{"josé 'ñ x'", []string{"josé", "ñ x"}, false},
+		{`next "`, nil, true},
+		{`next 'a`, nil, true},
+		{`next \`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := splitArgs(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitArgs(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+				t.Errorf("splitArgs(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplCommand_Complete(t *testing.T) {
+	fixture := NewTestFixture()
+	fixture.Config.Schedules = map[string]string{"primary": "P1", "db": "P2", "platform": "P3"}
+	fixture.MockClient.AddOnCall("USER002", "Jane Smith", "jane@example.com", time.Now(), time.Now().Add(time.Hour))
+	replCmd := NewReplCommand(fixture.Context)
+
+	tests := []struct {
+		line      string
+		want      []string
+		wantStart int
+	}{
+		{"", []string{"exit", "help", "next", "now", "override", "plan", "quit", "schedules", "set", "swap", "unset", "upcoming", "use"}, 0},
+		{"s", []string{"schedules", "set", "swap"}, 0},
+		{"set ", []string{"schedule", "tz", "user"}, 4},
+		{"use schedule pr", []string{"primary"}, 13},
+		{"set user j", []string{"john@example.com"}, 9},
+		{"override ", []string{"delete", "list"}, 9},
+		{"plan --sc", []string{"--schedule"}, 5},
+		{"plan --schedule p", []string{"platform", "primary"}, 16},
+		{"swap --with ", []string{"john@example.com"}, 12},
+		{`override --start "tomorrow 9`, nil, 27},
+		{"bogus --", nil, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, start := replCmd.complete(tt.line)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("complete(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+			if start != tt.wantStart {
+				t.Errorf("complete(%q) start = %d, want %d", tt.line, start, tt.wantStart)
+			}
+		})
+	}
+
+	// Users seen by commands become completions
+	replCmd.reader = strings.NewReader("plan\n")
+	if err := replCmd.Execute(context.Background(), nil); err != nil {
+		t.Fatalf("ReplCommand.Execute() failed: %v", err)
+	}
+	got, _ := replCmd.complete("override --target ")
+	if strings.Join(got, "|") != "jane@example.com|john@example.com" {
+		t.Errorf("complete() after plan = %q, want both users", got)
+	}
+}
+
+func TestReplCommand_Execute_SessionSettings(t *testing.T) {
+	fixture := NewTestFixture()
+	fixture.Config.Schedules = map[string]string{"db": "SCHED2"}
+	mock := fixture.MockClient
+	mock.AddSchedule("SCHED2", "Database", "UTC")
+	mock.AddUser("USER002", "Jane Smith", "jane@example.com")
+
+	replCmd := NewReplCommand(fixture.Context)
+	replCmd.reader = strings.NewReader(strings.Join([]string{
+		"use schedule db",
+		"set tz Europe/Berlin",
+		"set user jane@example.com",
+		"plan",
+		"next",
+		"set",
+		"unset schedule",
+		"plan",
+		"set tz Mars/Olympus",
+		"use schedule NOPE",
+		"set colour blue",
+		"quit",
+	}, "\n"))
+
+	if err := replCmd.Execute(context.Background(), nil); err != nil {
+		t.Fatalf("ReplCommand.Execute() failed: %v", err)
+	}
+
+	output := fixture.GetOutput()
+	for _, expected := range []string{
+		"Using schedule Database (SCHED2)",
+		"Using time zone Europe/Berlin",
+		"Using user Jane Smith <jane@example.com>",
+		"schedule  db (set in this session)",
+		"tz        Europe/Berlin (set in this session)",
+		"user      jane@example.com (set in this session)",
+		"Using schedule from config (SCHED123)",
+		"unknown time zone",
+		"error finding schedule NOPE",
+		"unknown setting: colour",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, output)
+		}
+	}
+
+	calls := mock.GetOnCallsCalls
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 GetOnCalls calls, got %d", len(calls))
+	}
+	if got := calls[0]["schedule_ids[]"]; len(got) != 1 || got[0] != "SCHED2" {
+		t.Errorf("plan after 'use schedule db' queried schedules %v, want SCHED2", got)
+	}
+	if since := calls[0].Get("since"); !strings.HasSuffix(since, "+01:00") && !strings.HasSuffix(since, "+02:00") {
+		t.Errorf("plan after 'set tz' queried since %q, want Berlin time", since)
+	}
+	if got := calls[1]["user_ids[]"]; len(got) != 1 || got[0] != "USER002" {
+		t.Errorf("next after 'set user' queried users %v, want USER002", got)
+	}
+	if got := calls[2]["schedule_ids[]"]; len(got) != 1 || got[0] != "SCHED123" {
+		t.Errorf("plan after 'unset schedule' queried schedules %v, want SCHED123", got)
+	}
+
+	// The user looked up by 'set user' is cached for 'next'
+	if len(mock.FindUserByEmailCalls) != 1 {
+		t.Errorf("expected 1 FindUserByEmail call, got %v", mock.FindUserByEmailCalls)
+	}
+	// The loaded config is left alone
+	if fixture.Config.DefaultSchedule != "" || fixture.Config.TimeZone != "UTC" || fixture.Config.MyUser != "john@example.com" {
+		t.Errorf("session settings changed the loaded config: %+v", fixture.Config)
+	}
+}
+
+func TestSessionClient_Caches(t *testing.T) {
+	mock := NewMockPagerDutyClient()
+	mock.AddUser("USER001", "John Doe", "john@example.com")
+	session := newSessionClient(mock)
+	ctx := context.Background()
+
+	// Lookups by email ignore case, as PagerDuty's do
+	for _, email := range []string{"john@example.com", "John@Example.com"} {
+		if _, err := session.FindUserByEmail(ctx, email); err != nil {
+			t.Fatalf("FindUserByEmail() failed: %v", err)
+		}
+		if _, err := session.GetUser(ctx, "USER001"); err != nil {
+			t.Fatalf("GetUser() failed: %v", err)
+		}
+		if _, err := session.FindUserByEmail(ctx, "nobody@example.com"); err == nil {
+			t.Fatal("expected an error for an unknown user")
+		}
+	}
+
+	if got := mock.FindUserByEmailCalls; len(got) != 3 {
+		t.Errorf("expected one lookup of the known user and two of the unknown one, got %v", got)
+	}
+	if got := mock.GetUserCalls; len(got) != 0 {
+		t.Errorf("expected GetUser to be answered from the cache, got %v", got)
+	}
+	if got := session.Emails(); len(got) != 1 || got[0] != "john@example.com" {
+		t.Errorf("Emails() = %v, want john@example.com", got)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package commands provides CLI command implementations for myshift-go.
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jdcasey/myshift-go/internal/config"
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// SchedulesCommand handles the "schedules" command functionality.
+type SchedulesCommand struct {
+	*BaseCommand
+	saveSetting func(key, value string) error
+}
+
+// NewSchedulesCommand creates a new SchedulesCommand instance.
+func NewSchedulesCommand(ctx *CommandContext) *SchedulesCommand {
+	base := NewBaseCommand(ctx.Client, ctx.Config, ctx.Writer)
+	base.reader = ctx.Reader
+
+	var profile string
+	if ctx.Config != nil {
+		profile = ctx.Config.Profile
+	}
+	return &SchedulesCommand{
+		BaseCommand: base,
+		saveSetting: func(key, value string) error {
+			return saveConfigSetting(profile, key, value)
+		},
+	}
+}
+
+// saveConfigSetting writes a single setting into the active configuration
+// file, within profile if one is selected.
+func saveConfigSetting(profile, key, value string) error {
+	path, err := config.FindConfigFile()
+	if err != nil {
+		return err
+	}
+	return config.SetProfileValue(path, profile, key, value)
+}
+
+// Execute runs the schedules command to list schedules and optionally save one as the default.
+func (s *SchedulesCommand) Execute(ctx context.Context, args []string) error {
+	flags, err := ParseSchedulesFlags(args)
+	if err != nil {
+		return err
+	}
+
+	// If flags is nil, help was displayed - exit gracefully
+	if flags == nil {
+		return nil
+	}
+
+	schedules, err := s.client.ListSchedules(ctx, flags.Query)
+	if err != nil {
+		return fmt.Errorf("error listing schedules: %w", err)
+	}
+
+	switch strings.ToLower(flags.Format) {
+	case "json":
+		return writeSchedulesJSON(s.writer, schedules)
+	case "text", "txt":
+		if err := writeSchedulesText(s.writer, schedules); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported format: %s (supported: text, json)", flags.Format)
+	}
+
+	if len(schedules) == 0 || !s.CanPrompt() {
+		return nil
+	}
+
+	return s.offerDefault(schedules)
+}
+
+// offerDefault asks the user to pick one of the listed schedules and saves its
+// ID as default_schedule in the configuration file, which takes precedence
+// over schedule_id.
+func (s *SchedulesCommand) offerDefault(schedules []types.Schedule) error {
+	answer, err := s.Prompt(fmt.Sprintf("\nSave a schedule as the default_schedule? Enter 1-%d (or press Enter to skip): ", len(schedules)))
+	if err != nil || answer == "" {
+		return nil
+	}
+
+	choice, err := strconv.Atoi(answer)
+	if err != nil || choice < 1 || choice > len(schedules) {
+		return fmt.Errorf("invalid selection: %s", answer)
+	}
+
+	selected := schedules[choice-1]
+	if err := s.saveSetting("default_schedule", selected.ID); err != nil {
+		return fmt.Errorf("error saving default_schedule: %w", err)
+	}
+	if s.config != nil {
+		s.config.DefaultSchedule = selected.ID
+	}
+
+	fmt.Fprintf(s.writer, "Saved default_schedule: %s (%s)\n", selected.ID, selected.Name)
+	return nil
+}
+
+// writeSchedulesText writes schedules as a numbered, aligned table.
+func writeSchedulesText(writer io.Writer, schedules []types.Schedule) error {
+	if len(schedules) == 0 {
+		_, err := fmt.Fprintln(writer, "No schedules found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tID\tNAME\tTIME ZONE\tTEAMS")
+	for i, schedule := range schedules {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", i+1, schedule.ID, schedule.Name, schedule.TimeZone, teamNames(schedule.Teams))
+	}
+	return tw.Flush()
+}
+
+// writeSchedulesJSON writes schedules as an indented JSON array.
+func writeSchedulesJSON(writer io.Writer, schedules []types.Schedule) error {
+	if schedules == nil {
+		schedules = []types.Schedule{}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(schedules)
+}
+
+// teamNames joins the names of a schedule's teams for display.
+func teamNames(teams []types.Team) string {
+	if len(teams) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(teams))
+	for _, team := range teams {
+		names = append(names, team.Summary)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Usage returns the usage information for the schedules command
+func (s *SchedulesCommand) Usage() string {
+	return `Usage: myshift schedules [options]
+
+Options:
+  --query string       Only show schedules whose name matches this text
+  --format, -o string  Output format: text, json (default: text)
+
+In text mode, you are offered the chance to save one of the listed
+schedules as default_schedule in your configuration file.
+
+`
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+func TestSchedulesCommand_Execute(t *testing.T) {
+	tests := []struct {
+		testName    string
+		args        []string
+		wantErr     bool
+		wantOutput  []string
+		avoidOutput []string
+	}{
+		{
+			testName:   "list all schedules",
+			args:       []string{},
+			wantOutput: []string{"ID", "TIME ZONE", "PPRIMARY", "Primary On-Call", "America/New_York", "Platform", "PDB", "Database"},
+		},
+		{
+			testName:    "filter by query",
+			args:        []string{"--query", "data"},
+			wantOutput:  []string{"PDB", "Database"},
+			avoidOutput: []string{"PPRIMARY"},
+		},
+		{
+			testName:   "no matches",
+			args:       []string{"--query", "nothing"},
+			wantOutput: []string{"No schedules found"},
+		},
+		{
+			testName: "unsupported format",
+			args:     []string{"--format", "xml"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			fixture.MockClient.AddSchedule("PPRIMARY", "Primary On-Call", "America/New_York", "Platform")
+			fixture.MockClient.AddSchedule("PDB", "Database On-Call", "Europe/Berlin")
+
+			cmd := NewSchedulesCommand(fixture.Context)
+
+			err := cmd.Execute(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SchedulesCommand.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !fixture.ContainsOutput(tt.wantOutput...) {
+				t.Errorf("Expected output to contain %v, got:\n%s", tt.wantOutput, fixture.GetOutput())
+			}
+			for _, avoid := range tt.avoidOutput {
+				if strings.Contains(fixture.GetOutput(), avoid) {
+					t.Errorf("Expected output not to contain %q, got:\n%s", avoid, fixture.GetOutput())
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulesCommand_Execute_JSON(t *testing.T) {
+	fixture := NewTestFixture()
+	fixture.MockClient.AddSchedule("PPRIMARY", "Primary On-Call", "America/New_York", "Platform")
+
+	cmd := NewSchedulesCommand(fixture.Context)
+
+	if err := cmd.Execute(context.Background(), []string{"--format", "json"}); err != nil {
+		t.Fatalf("SchedulesCommand.Execute() failed: %v", err)
+	}
+
+	var schedules []types.Schedule
+	if err := json.Unmarshal(fixture.Buffer.Bytes(), &schedules); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, fixture.GetOutput())
+	}
+	if len(schedules) != 1 || schedules[0].ID != "PPRIMARY" || schedules[0].Teams[0].Summary != "Platform" {
+		t.Errorf("Unexpected schedules: %+v", schedules)
+	}
+}
+
+func TestSchedulesCommand_Execute_SaveDefault(t *testing.T) {
+	fixture := NewTestFixture()
+	fixture.MockClient.AddSchedule("PPRIMARY", "Primary On-Call", "America/New_York")
+	fixture.MockClient.AddSchedule("PDB", "Database On-Call", "Europe/Berlin")
+	fixture.Context.Reader = strings.NewReader("2\n")
+	fixture.Config.DefaultSchedule = "PPRIMARY"
+
+	cmd := NewSchedulesCommand(fixture.Context)
+	saved := map[string]string{}
+	cmd.saveSetting = func(key, value string) error {
+		saved[key] = value
+		return nil
+	}
+
+	if err := cmd.Execute(context.Background(), nil); err != nil {
+		t.Fatalf("SchedulesCommand.Execute() failed: %v", err)
+	}
+
+	if saved["default_schedule"] != "PDB" {
+		t.Errorf("Expected default_schedule PDB to be saved, got %v", saved)
+	}
+	// The saved schedule replaces the previous default rather than being
+	// shadowed by it
+	if id, err := cmd.defaultScheduleID(); err != nil || id != "PDB" {
+		t.Errorf("Expected PDB to be the default schedule, got %q (%v)", id, err)
+	}
+	if !fixture.ContainsOutput("Saved default_schedule: PDB") {
+		t.Errorf("Expected confirmation output, got:\n%s", fixture.GetOutput())
+	}
+}
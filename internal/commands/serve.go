@@ -0,0 +1,483 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/pagerduty"
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// serveShutdownTimeout bounds how long the server waits for requests in flight
+// when it is stopped.
+const serveShutdownTimeout = 10 * time.Second
+
+// feedIdleTimeout is how long a feed stays cached, and keeps being refreshed,
+// after it was last requested.
+const feedIdleTimeout = 24 * time.Hour
+
+// ServeCommand handles the "serve" command functionality.
+type ServeCommand struct {
+	*BaseCommand
+}
+
+// NewServeCommand creates a new ServeCommand instance.
+func NewServeCommand(ctx *CommandContext) *ServeCommand {
+	return &ServeCommand{
+		BaseCommand: NewBaseCommand(ctx.Client, ctx.Config, ctx.Writer),
+	}
+}
+
+// Execute runs the serve command, publishing calendar and JSON feeds over HTTP
+// until ctx is cancelled.
+func (s *ServeCommand) Execute(ctx context.Context, args []string) error {
+	flags, err := ParseServeFlags(args)
+	if err != nil {
+		return err
+	}
+
+	// If flags is nil, help was displayed - exit gracefully
+	if flags == nil {
+		return nil
+	}
+
+	alarms, err := parseAlarms(flags.Alarms)
+	if err != nil {
+		return err
+	}
+
+	loc, err := s.Location(ctx, flags.TimeZone, "")
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", flags.Listen)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", flags.Listen, err)
+	}
+
+	feeds := newFeedServer(s.BaseCommand, flags.TTL, flags.Days, alarms, loc)
+	server := &http.Server{
+		Handler:           feeds,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	refreshCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go feeds.refreshLoop(refreshCtx)
+
+	fmt.Fprintf(s.writer, "Serving on http://%s (refreshing every %s)\n", listener.Addr(), flags.TTL)
+	fmt.Fprintln(s.writer, "  /schedules/{alias}.ics  Shifts on a schedule, by alias or ID")
+	fmt.Fprintln(s.writer, "  /users/{email}.ics      Shifts of a user on every schedule")
+	fmt.Fprintln(s.writer, "  /now.json               Who is on call right now")
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("error serving: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.WithoutCancel(ctx), serveShutdownTimeout)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("error stopping server: %w", err)
+	}
+	fmt.Fprintln(s.writer, "Server stopped")
+
+	return nil
+}
+
+// Usage returns the usage information for the serve command
+func (s *ServeCommand) Usage() string {
+	return `Usage: myshift serve [options]
+
+Publish on-call feeds over HTTP for calendar apps and dashboards:
+
+  /schedules/{alias}.ics  Shifts on a schedule, by alias or ID
+  /users/{email}.ics      Shifts of a user on every schedule
+  /now.json               Who is on call right now
+
+Feeds are cached and refreshed in the background. Responses carry an ETag,
+so clients polling with If-None-Match get 304 Not Modified until the
+shifts change.
+
+Options:
+  --listen string   Address to listen on (default: :8080)
+  --ttl duration    How long feeds are cached before they are refreshed (default: 5m)
+  --days int        Number of days of shifts in calendar feeds (default: 90)
+  --tz string       Time zone for now.json: an IANA name, local, or schedule
+  --alarm string    Add a reminder this long before each shift in calendar feeds; repeatable
+
+`
+}
+
+// feedServer serves calendar and JSON feeds from a cache. Each feed is loaded
+// when it is first requested and then refreshed in the background every ttl,
+// so requests are answered from the cache without waiting for PagerDuty.
+type feedServer struct {
+	base   *BaseCommand
+	ttl    time.Duration
+	days   int
+	alarms []time.Duration
+	loc    *time.Location
+	now    func() time.Time
+
+	mu        sync.Mutex // Guards feeds, the cached content of each feed, and revisions
+	feeds     map[string]*feed
+	revisions map[string]shiftRevision // By iCal UID
+}
+
+// shiftRevision records how a shift looked when it was last served and when
+// that last changed, so that iCal SEQUENCE numbers only increase for shifts
+// that actually changed.
+type shiftRevision struct {
+	content string
+	end     time.Time
+	changed time.Time
+}
+
+// feed is the cached response for one URL path.
+type feed struct {
+	load        feedLoader
+	body        []byte
+	contentType string
+	etag        string
+	modified    time.Time // When body last changed
+	requested   time.Time // When the feed was last requested
+}
+
+// feedLoader fetches the data for a feed and returns how to render it.
+type feedLoader func(ctx context.Context, now time.Time) (*feedContent, error)
+
+// feedContent renders a feed. generated is the time reported as when the
+// feed was generated, such as iCal DTSTAMP values; times shifts last changed
+// are fixed when the feed is loaded.
+type feedContent struct {
+	contentType string
+	render      func(w io.Writer, generated time.Time) error
+}
+
+// newFeedServer creates a feedServer that fetches shifts through base.
+func newFeedServer(base *BaseCommand, ttl time.Duration, days int, alarms []time.Duration, loc *time.Location) *feedServer {
+	return &feedServer{
+		base:      base,
+		ttl:       ttl,
+		days:      days,
+		alarms:    alarms,
+		loc:       loc,
+		now:       time.Now,
+		feeds:     make(map[string]*feed),
+		revisions: make(map[string]shiftRevision),
+	}
+}
+
+// ServeHTTP implements http.Handler. Conditional requests are answered by
+// http.ServeContent using the feed's ETag and modification time.
+func (s *feedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	load := s.route(r.URL.Path)
+	if load == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := s.lookup(r.Context(), r.URL.Path, load)
+	if err != nil {
+		if pagerduty.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	body, contentType, etag, modified := f.body, f.contentType, f.etag, f.modified
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(s.ttl/time.Second)))
+	http.ServeContent(w, r, "", modified, bytes.NewReader(body))
+}
+
+// route returns the loader for the feed at path, or nil if there is none.
+func (s *feedServer) route(path string) feedLoader {
+	if path == "/now.json" {
+		return s.loadNow
+	}
+	if alias, ok := feedName(path, "/schedules/", ".ics"); ok {
+		return func(ctx context.Context, now time.Time) (*feedContent, error) {
+			return s.loadSchedule(ctx, alias, now)
+		}
+	}
+	if email, ok := feedName(path, "/users/", ".ics"); ok {
+		return func(ctx context.Context, now time.Time) (*feedContent, error) {
+			return s.loadUser(ctx, email, now)
+		}
+	}
+	return nil
+}
+
+// feedName extracts the name from a path of the form prefix + name + suffix.
+func feedName(path, prefix, suffix string) (string, bool) {
+	if !strings.HasPrefix(path, prefix) || !strings.HasSuffix(path, suffix) {
+		return "", false
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(path, prefix), suffix)
+	if name == "" || strings.Contains(name, "/") {
+		return "", false
+	}
+	return name, true
+}
+
+// lookup returns the cached feed for path, loading it first if it has not
+// been requested before. Feeds that fail to load are not cached.
+func (s *feedServer) lookup(ctx context.Context, path string, load feedLoader) (*feed, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	f, ok := s.feeds[path]
+	if ok {
+		f.requested = now
+	}
+	s.mu.Unlock()
+	if ok {
+		return f, nil
+	}
+
+	f = &feed{load: load, requested: now}
+	if err := s.refresh(ctx, f); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.feeds[path]; ok {
+		// Another request loaded it at the same time
+		existing.requested = now
+		return existing, nil
+	}
+	s.feeds[path] = f
+	return f, nil
+}
+
+// refresh reloads a feed. When the content is unchanged the body, ETag and
+// modification time are kept, so clients polling with If-None-Match keep
+// getting 304 Not Modified even though the feed was regenerated.
+func (s *feedServer) refresh(ctx context.Context, f *feed) error {
+	now := s.now()
+	content, err := f.load(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	previous, modified := f.body, f.modified
+	s.mu.Unlock()
+
+	var buf bytes.Buffer
+	if previous != nil {
+		// Render as if generated at the same time as the cached body, so only
+		// real changes to the shifts make the bodies differ
+		if err := content.render(&buf, modified); err != nil {
+			return err
+		}
+		if bytes.Equal(buf.Bytes(), previous) {
+			return nil
+		}
+		buf.Reset()
+	}
+
+	if err := content.render(&buf, now); err != nil {
+		return err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f.body = buf.Bytes()
+	f.contentType = content.contentType
+	f.etag = fmt.Sprintf(`"%x"`, sum[:16])
+	f.modified = now
+	return nil
+}
+
+// refreshLoop refreshes cached feeds every ttl until ctx is cancelled.
+func (s *feedServer) refreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshAll(ctx)
+		}
+	}
+}
+
+// refreshAll refreshes every cached feed and forgets those that have not been
+// requested for feedIdleTimeout. A feed that fails to refresh keeps serving
+// its previous content.
+func (s *feedServer) refreshAll(ctx context.Context) {
+	now := s.now()
+
+	s.mu.Lock()
+	active := make(map[string]*feed, len(s.feeds))
+	for path, f := range s.feeds {
+		if now.Sub(f.requested) > feedIdleTimeout {
+			delete(s.feeds, path)
+			continue
+		}
+		active[path] = f
+	}
+	for uid, revision := range s.revisions {
+		if revision.end.Before(now) {
+			delete(s.revisions, uid)
+		}
+	}
+	s.mu.Unlock()
+
+	for path, f := range active {
+		if err := s.refresh(ctx, f); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(s.base.writer, "Error refreshing %s: %v\n", path, err)
+		}
+	}
+}
+
+// loadSchedule loads the calendar feed of the schedule with the given alias or ID.
+func (s *feedServer) loadSchedule(ctx context.Context, alias string, now time.Time) (*feedContent, error) {
+	scheduleID, err := s.base.ResolveScheduleID(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.base.client.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching schedule %s: %w", scheduleID, err)
+	}
+
+	start := now.In(s.loc)
+	end := start.AddDate(0, 0, s.days)
+	onCalls, err := s.base.GetOnCallsForSchedules(ctx, []string{scheduleID}, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.calendar(onCalls, "On-call: "+schedule.Name, start, end, now), nil
+}
+
+// loadUser loads the calendar feed of the user with the given email.
+func (s *feedServer) loadUser(ctx context.Context, email string, now time.Time) (*feedContent, error) {
+	user, err := s.base.client.FindUserByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("error finding user %s: %w", email, err)
+	}
+
+	start := now.In(s.loc)
+	end := start.AddDate(0, 0, s.days)
+	onCalls, err := s.base.GetOnCallsForUser(ctx, nil, user.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Escalation policy entries without a schedule have no shift times
+	var shifts []types.OnCall
+	for _, shift := range onCalls {
+		if !shift.Start.IsZero() && !shift.End.IsZero() {
+			shifts = append(shifts, shift)
+		}
+	}
+
+	return s.calendar(shifts, "On-call: "+user.Name, start, end, now), nil
+}
+
+// loadNow loads the JSON feed of who is on call at now. The document's start
+// and end are its generation time, so refreshes that find the same people on
+// call render the same body.
+func (s *feedServer) loadNow(ctx context.Context, now time.Time) (*feedContent, error) {
+	now = now.In(s.loc)
+	onCalls, err := s.base.GetCurrentOnCalls(ctx, nil, nil, now)
+	if err != nil {
+		return nil, err
+	}
+	onCalls = ShiftsIn(onCalls, s.loc)
+
+	return &feedContent{
+		contentType: "application/json",
+		render: func(w io.Writer, generated time.Time) error {
+			generated = generated.In(s.loc)
+			return NewJSONFormatter().Format(w, onCalls, nil, generated, generated)
+		},
+	}, nil
+}
+
+// calendar returns content rendering shifts, loaded at now, as an iCal feed
+// named name.
+func (s *feedServer) calendar(shifts []types.OnCall, name string, start, end, now time.Time) *feedContent {
+	shifts = ShiftsIn(shifts, s.loc)
+	changed := s.revise(shifts, now)
+	return &feedContent{
+		contentType: "text/calendar; charset=utf-8",
+		render: func(w io.Writer, generated time.Time) error {
+			formatter := NewICalFormatter(s.alarms, name)
+			formatter.now = func() time.Time { return generated }
+			formatter.changed = func(shift types.OnCall) time.Time { return changed[icalUID(shift)] }
+			return formatter.Format(w, shifts, nil, start, end)
+		},
+	}
+}
+
+// revise records the shifts as loaded at now and returns when each one last
+// changed, by iCal UID. A shift not seen before counts as changed at now.
+func (s *feedServer) revise(shifts []types.OnCall, now time.Time) map[string]time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := make(map[string]time.Time, len(shifts))
+	for _, shift := range shifts {
+		uid := icalUID(shift)
+		content := fmt.Sprintf("%s|%s|%s", shift.End.UTC().Format(time.RFC3339), shift.User.Name, shift.Schedule.Name)
+		revision, ok := s.revisions[uid]
+		if !ok || revision.content != content {
+			revision = shiftRevision{content: content, end: shift.End, changed: now}
+			s.revisions[uid] = revision
+		}
+		changed[uid] = revision.changed
+	}
+	return changed
+}
@@ -0,0 +1,322 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestFeedServer creates a feedServer over the fixture with a fixed clock,
+// and the fixture's schedule with two shifts in the following week.
+func newTestFeedServer(fixture *TestFixture) (*feedServer, *time.Time) {
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	mock := fixture.MockClient
+	mock.AddSchedule("SCHED123", "Test Schedule", "UTC")
+	mock.AddUser("USER002", "Jane Smith", "jane@example.com")
+	mock.AddOnCall("USER001", "John Doe", "john@example.com", now.Add(24*time.Hour), now.Add(48*time.Hour))
+	mock.AddOnCall("USER002", "Jane Smith", "jane@example.com", now.Add(48*time.Hour), now.Add(72*time.Hour))
+
+	base := NewBaseCommand(fixture.Context.Client, fixture.Context.Config, fixture.Context.Writer)
+	server := newFeedServer(base, 5*time.Minute, 30, nil, time.UTC)
+	server.now = func() time.Time { return now }
+	return server, &now
+}
+
+// serveFeed makes a request to server and returns the response.
+func serveFeed(server http.Handler, method, path string, header http.Header) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, nil)
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestFeedServer_Feeds(t *testing.T) {
+	tests := []struct {
+		testName         string
+		path             string
+		wantContentType  string
+		expectedOutput   []string
+		unexpectedOutput []string
+	}{
+		{
+			testName:        "schedule feed",
+			path:            "/schedules/SCHED123.ics",
+			wantContentType: "text/calendar; charset=utf-8",
+			expectedOutput: []string{
+				"BEGIN:VCALENDAR", "X-WR-CALNAME:On-call: Test Schedule",
+				"SUMMARY:On-Call: John Doe", "SUMMARY:On-Call: Jane Smith",
+			},
+		},
+		{
+			testName:         "user feed",
+			path:             "/users/jane@example.com.ics",
+			wantContentType:  "text/calendar; charset=utf-8",
+			expectedOutput:   []string{"X-WR-CALNAME:On-call: Jane Smith", "SUMMARY:On-Call: Jane Smith"},
+			unexpectedOutput: []string{"John Doe"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			server, _ := newTestFeedServer(fixture)
+
+			response := serveFeed(server, http.MethodGet, tt.path, nil)
+			if response.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", response.Code, response.Body.String())
+			}
+			if got := response.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if response.Header().Get("ETag") == "" {
+				t.Error("expected an ETag header")
+			}
+			if got := response.Header().Get("Cache-Control"); got != "max-age=300" {
+				t.Errorf("Cache-Control = %q, want max-age=300", got)
+			}
+
+			body := response.Body.String()
+			for _, expected := range tt.expectedOutput {
+				if !strings.Contains(body, expected) {
+					t.Errorf("expected %q in body:\n%s", expected, body)
+				}
+			}
+			for _, unexpected := range tt.unexpectedOutput {
+				if strings.Contains(body, unexpected) {
+					t.Errorf("did not expect %q in body:\n%s", unexpected, body)
+				}
+			}
+		})
+	}
+}
+
+func TestFeedServer_Now(t *testing.T) {
+	fixture := NewTestFixture()
+	addCurrentOnCalls(fixture)
+	server, now := newTestFeedServer(fixture)
+	*now = time.Now()
+
+	response := serveFeed(server, http.MethodGet, "/now.json", nil)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", response.Code, response.Body.String())
+	}
+	if got := response.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+
+	var document JSONDocument
+	if err := json.Unmarshal(response.Body.Bytes(), &document); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(document.Shifts) != 3 {
+		t.Errorf("got %d shifts, want 3", len(document.Shifts))
+	}
+}
+
+func TestFeedServer_Errors(t *testing.T) {
+	tests := []struct {
+		testName   string
+		method     string
+		path       string
+		failAPI    bool
+		wantStatus int
+	}{
+		{"unknown path", http.MethodGet, "/feeds.ics", false, http.StatusNotFound},
+		{"empty schedule", http.MethodGet, "/schedules/.ics", false, http.StatusNotFound},
+		{"nested path", http.MethodGet, "/schedules/a/b.ics", false, http.StatusNotFound},
+		{"unknown schedule", http.MethodGet, "/schedules/NOPE.ics", false, http.StatusNotFound},
+		{"unknown user", http.MethodGet, "/users/nobody@example.com.ics", false, http.StatusNotFound},
+		{"method not allowed", http.MethodPost, "/now.json", false, http.StatusMethodNotAllowed},
+		{"API failure", http.MethodGet, "/schedules/SCHED123.ics", true, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			server, _ := newTestFeedServer(fixture)
+			fixture.MockClient.SetErrorOnOnCalls(tt.failAPI)
+
+			response := serveFeed(server, tt.method, tt.path, nil)
+			if response.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", response.Code, tt.wantStatus, response.Body.String())
+			}
+			if len(server.feeds) != 0 {
+				t.Errorf("failed request cached %d feed(s)", len(server.feeds))
+			}
+		})
+	}
+}
+
+func TestFeedServer_Caching(t *testing.T) {
+	fixture := NewTestFixture()
+	server, now := newTestFeedServer(fixture)
+	mock := fixture.MockClient
+	path := "/schedules/SCHED123.ics"
+
+	first := serveFeed(server, http.MethodGet, path, nil)
+	etag := first.Header().Get("ETag")
+	conditional := http.Header{"If-None-Match": []string{etag}}
+
+	// Requests are answered from the cache
+	response := serveFeed(server, http.MethodGet, path, conditional)
+	if response.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want 304", response.Code)
+	}
+	if len(mock.GetOnCallsCalls) != 1 {
+		t.Errorf("GetOnCalls called %d times, want 1", len(mock.GetOnCallsCalls))
+	}
+
+	// A refresh that finds the same shifts keeps the ETag, despite the new generation time
+	*now = now.Add(5 * time.Minute)
+	server.refreshAll(context.Background())
+	if len(mock.GetOnCallsCalls) != 2 {
+		t.Errorf("GetOnCalls called %d times after refresh, want 2", len(mock.GetOnCallsCalls))
+	}
+	response = serveFeed(server, http.MethodGet, path, conditional)
+	if response.Code != http.StatusNotModified {
+		t.Errorf("status after unchanged refresh = %d, want 304", response.Code)
+	}
+
+	// A refresh that finds a new shift changes the body and ETag
+	mock.AddOnCall("USER001", "John Doe", "john@example.com", now.Add(96*time.Hour), now.Add(120*time.Hour))
+	*now = now.Add(5 * time.Minute)
+	server.refreshAll(context.Background())
+	response = serveFeed(server, http.MethodGet, path, conditional)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status after changed refresh = %d, want 200", response.Code)
+	}
+	if response.Header().Get("ETag") == etag {
+		t.Error("expected the ETag to change with the shifts")
+	}
+	if got := strings.Count(response.Body.String(), "BEGIN:VEVENT"); got != 3 {
+		t.Errorf("got %d events, want 3", got)
+	}
+	// Only the new shift is marked as modified
+	if got := strings.Count(response.Body.String(), "LAST-MODIFIED:20250303T120000Z"); got != 2 {
+		t.Errorf("got %d shifts last modified at the first load, want 2:\n%s", got, response.Body.String())
+	}
+	if !strings.Contains(response.Body.String(), "LAST-MODIFIED:20250303T121000Z") {
+		t.Errorf("expected the new shift to be modified at the refresh, got:\n%s", response.Body.String())
+	}
+
+	// A failed refresh keeps serving the cached feed
+	mock.SetErrorOnOnCalls(true)
+	server.refreshAll(context.Background())
+	if !fixture.ContainsOutput("Error refreshing " + path) {
+		t.Errorf("expected the refresh error to be reported, got: %s", fixture.GetOutput())
+	}
+	if response := serveFeed(server, http.MethodGet, path, nil); response.Code != http.StatusOK {
+		t.Errorf("status after failed refresh = %d, want 200", response.Code)
+	}
+
+	// Feeds nobody requests are forgotten
+	*now = now.Add(feedIdleTimeout + time.Minute)
+	server.refreshAll(context.Background())
+	if len(server.feeds) != 0 {
+		t.Errorf("expected idle feeds to be dropped, %d remain", len(server.feeds))
+	}
+}
+
+func TestFeedServer_CachingNow(t *testing.T) {
+	fixture := NewTestFixture()
+	server, now := newTestFeedServer(fixture)
+	mock := fixture.MockClient
+	mock.AddOnCall("USER001", "John Doe", "john@example.com", now.Add(-time.Hour), now.Add(time.Hour))
+	path := "/now.json"
+
+	first := serveFeed(server, http.MethodGet, path, nil)
+	etag := first.Header().Get("ETag")
+	conditional := http.Header{"If-None-Match": []string{etag}}
+
+	// A refresh that finds the same people on call keeps the body and ETag
+	*now = now.Add(5 * time.Minute)
+	server.refreshAll(context.Background())
+	response := serveFeed(server, http.MethodGet, path, conditional)
+	if response.Code != http.StatusNotModified {
+		t.Errorf("status after unchanged refresh = %d, want 304", response.Code)
+	}
+
+	// A refresh after the shift ends changes the ETag
+	*now = now.Add(2 * time.Hour)
+	server.refreshAll(context.Background())
+	response = serveFeed(server, http.MethodGet, path, conditional)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status after changed refresh = %d, want 200", response.Code)
+	}
+	if response.Header().Get("ETag") == etag {
+		t.Error("expected the ETag to change with the people on call")
+	}
+}
+
+func TestServeCommand_Execute(t *testing.T) {
+	tests := []struct {
+		testName string
+		args     []string
+		wantErr  string
+	}{
+		{"help", []string{"--help"}, ""},
+		{"invalid ttl", []string{"--ttl", "0s"}, "--ttl must be positive"},
+		{"invalid days", []string{"--days", "0"}, "--days must be positive"},
+		{"invalid alarm", []string{"--alarm", "soon"}, "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			cmd := NewServeCommand(fixture.Context)
+
+			err := cmd.Execute(context.Background(), tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestServeCommand_Execute_Serves(t *testing.T) {
+	fixture := NewTestFixture()
+	cmd := NewServeCommand(fixture.Context)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Execute(ctx, []string{"--listen", "127.0.0.1:0"})
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop when the context was cancelled")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitArgs splits a command line into arguments the way a POSIX shell does,
+// without variable or glob expansion. Unquoted spaces and tabs separate
+// arguments. Single quotes keep everything up to the closing quote as is.
+// Double quotes keep everything up to the closing quote, except that a
+// backslash escapes a following ", \ or $. Outside quotes, a backslash escapes
+// any character. Quotes may appear within an argument, and "" is an empty
+// argument.
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+
+	// Quotes, backslashes and separators are all ASCII, so stepping through
+	// bytes leaves multi-byte characters intact
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch c {
+		case ' ', '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		case '\\':
+			if i+1 == len(line) {
+				return nil, fmt.Errorf("unfinished escape at end of line")
+			}
+			i++
+			current.WriteByte(line[i])
+			inArg = true
+		case '\'':
+			end := strings.IndexByte(line[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			current.WriteString(line[i+1 : i+1+end])
+			i += end + 1
+			inArg = true
+		case '"':
+			closed := false
+			for i++; i < len(line); i++ {
+				c := line[i]
+				if c == '"' {
+					closed = true
+					break
+				}
+				if c == '\\' && i+1 < len(line) && strings.IndexByte(`"\$`, line[i+1]) >= 0 {
+					i++
+					c = line[i]
+				}
+				current.WriteByte(c)
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inArg = true
+		default:
+			current.WriteByte(c)
+			inArg = true
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// rollbackTimeout bounds how long a swap spends deleting overrides it already
+// created, even when the command itself was cancelled.
+const rollbackTimeout = 30 * time.Second
+
+// SwapCommand handles the "swap" command functionality.
+type SwapCommand struct {
+	*BaseCommand
+}
+
+// NewSwapCommand creates a new SwapCommand instance.
+func NewSwapCommand(ctx *CommandContext) *SwapCommand {
+	base := NewBaseCommand(ctx.Client, ctx.Config, ctx.Writer)
+	base.reader = ctx.Reader
+	return &SwapCommand{
+		BaseCommand: base,
+	}
+}
+
+// Execute runs the swap command. It hands the user's shift on one day to
+// another user and takes that user's shift on another day, creating both
+// overrides or neither.
+func (s *SwapCommand) Execute(ctx context.Context, args []string) error {
+	flags, err := ParseSwapFlags(args)
+	if err != nil {
+		return err
+	}
+
+	// If flags is nil, help was displayed - exit gracefully
+	if flags == nil {
+		return nil
+	}
+
+	scheduleID, err := s.ResolveScheduleID(flags.Schedule)
+	if err != nil {
+		return err
+	}
+
+	// Days start at midnight in the chosen time zone
+	loc, err := s.Location(ctx, flags.TimeZone, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	mineDay, err := parseSwapDay(flags.Mine, loc)
+	if err != nil {
+		return fmt.Errorf("invalid --mine date: %w", err)
+	}
+	theirsDay, err := parseSwapDay(flags.Theirs, loc)
+	if err != nil {
+		return fmt.Errorf("invalid --theirs date: %w", err)
+	}
+
+	me, err := s.ResolveUser(ctx, flags.User)
+	if err != nil {
+		return fmt.Errorf("error finding user: %w", err)
+	}
+	them, err := s.client.FindUserByEmail(ctx, flags.With)
+	if err != nil {
+		return fmt.Errorf("error finding user %s: %w", flags.With, err)
+	}
+	if me.ID == them.ID {
+		return fmt.Errorf("cannot swap shifts with yourself")
+	}
+
+	myShifts, err := s.shiftsStartingOn(ctx, scheduleID, me, mineDay)
+	if err != nil {
+		return err
+	}
+	theirShifts, err := s.shiftsStartingOn(ctx, scheduleID, them, theirsDay)
+	if err != nil {
+		return err
+	}
+
+	// They cover my shift and I cover theirs; a swap always trades whole shifts
+	give := PlanOverrides(myShifts, them, mineDay, mineDay.AddDate(0, 0, 1), true, loc)
+	take := PlanOverrides(theirShifts, me, theirsDay, theirsDay.AddDate(0, 0, 1), true, loc)
+	planned := append(append([]PlannedOverride{}, give...), take...)
+
+	WriteOverridePlan(s.writer, planned, scheduleID)
+
+	if flags.DryRun {
+		fmt.Fprintln(s.writer, "Dry run: no overrides were created")
+		return nil
+	}
+
+	if !flags.Yes {
+		if !s.CanPrompt() {
+			return fmt.Errorf("refusing to swap shifts without confirmation (use --yes)")
+		}
+		confirmed, err := s.Confirm(fmt.Sprintf("Create %d override(s)?", len(planned)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(s.writer, "Aborted; no overrides were created")
+			return nil
+		}
+	}
+
+	created, err := s.client.CreateOverrides(ctx, scheduleID, OverridesFromPlan(give))
+	if err != nil {
+		return s.rollback(ctx, scheduleID, created, fmt.Errorf("error creating overrides for your shift: %w", err))
+	}
+
+	taken, err := s.client.CreateOverrides(ctx, scheduleID, OverridesFromPlan(take))
+	created = append(created, taken...)
+	if err != nil {
+		return s.rollback(ctx, scheduleID, created, fmt.Errorf("error creating overrides for %s's shift: %w", them.Name, err))
+	}
+
+	fmt.Fprintf(s.writer, "Successfully swapped shifts with %s (%d override(s) created)\n", them.Name, len(created))
+
+	return nil
+}
+
+// parseSwapDay parses a date expression (see ParseDateExpression) in loc and
+// returns midnight at the start of that day.
+func parseSwapDay(value string, loc *time.Location) (time.Time, error) {
+	t, err := ParseDateExpression(value, time.Now().In(loc))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), nil
+}
+
+// shiftsStartingOn returns the user's shifts that start on the given day. A
+// shift that merely runs into the day from the previous one is not included.
+// The day ends at the next midnight in its location, so it may be 23 or 25
+// hours long across a daylight saving change.
+func (s *SwapCommand) shiftsStartingOn(ctx context.Context, scheduleID string, user *types.User, day time.Time) ([]types.OnCall, error) {
+	dayEnd := day.AddDate(0, 0, 1)
+
+	onCalls, err := s.GetOnCallsForUser(ctx, []string{scheduleID}, user.ID, day, dayEnd)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching shifts for %s: %w", user.Email, err)
+	}
+
+	var shifts []types.OnCall
+	for _, onCall := range onCalls {
+		if !onCall.Start.Before(day) && onCall.Start.Before(dayEnd) {
+			shifts = append(shifts, ShiftsIn([]types.OnCall{onCall}, day.Location())...)
+		}
+	}
+
+	if len(shifts) == 0 {
+		return nil, fmt.Errorf("no shifts found for %s starting on %s", user.Email, day.Format("2006-01-02"))
+	}
+	return shifts, nil
+}
+
+// rollback deletes overrides created before a swap failed, so that a swap is
+// never left half done. It uses a context detached from ctx, since the failure
+// may be the command being cancelled. The returned error wraps cause and lists
+// any overrides that could not be removed.
+func (s *SwapCommand) rollback(ctx context.Context, scheduleID string, created []types.Override, cause error) error {
+	if len(created) == 0 {
+		return cause
+	}
+
+	rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+
+	fmt.Fprintf(s.writer, "Rolling back %d override(s)\n", len(created))
+
+	var remaining []string
+	for _, override := range created {
+		if override.ID == "" {
+			fmt.Fprintf(s.writer, "Cannot roll back override starting %s: PagerDuty did not return its ID\n", override.Start.Format("2006-01-02 15:04 MST"))
+			remaining = append(remaining, "override starting "+override.Start.Format("2006-01-02 15:04 MST"))
+			continue
+		}
+		if err := s.client.DeleteOverride(rollbackCtx, scheduleID, override.ID); err != nil {
+			fmt.Fprintf(s.writer, "Failed to roll back override %s: %v\n", override.ID, err)
+			remaining = append(remaining, override.ID)
+			continue
+		}
+		fmt.Fprintf(s.writer, "Rolled back override %s\n", override.ID)
+	}
+
+	if len(remaining) > 0 {
+		return fmt.Errorf("%w; rollback incomplete, delete these overrides manually: %s", cause, strings.Join(remaining, ", "))
+	}
+	return fmt.Errorf("%w; no shifts were swapped", cause)
+}
+
+// Usage returns the usage information for the swap command
+func (s *SwapCommand) Usage() string {
+	return `Usage: myshift swap --with email --mine date --theirs date [options]
+
+Trade shifts with another user: they take your shift starting on --mine and
+you take theirs starting on --theirs. If either half fails, the overrides
+already created are deleted again.
+
+Options:
+  --user string     Your email (uses my_user from config if not provided)
+  --with string     Email of the user to swap with (required)
+  --mine string     Date of your shift to hand over, e.g. 2025-03-04 or 'next tuesday' (required)
+  --theirs string   Date of their shift to take, e.g. 2025-03-08 or +2w (required)
+  --schedule string Schedule alias or ID (uses the default schedule if not provided)
+  --tz string       Time zone for dates and times: an IANA name, local, or schedule
+  --dry-run         Show the overrides that would be created without creating them
+  --yes             Create the overrides without asking for confirmation
+
+`
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSwapCommand_Execute(t *testing.T) {
+	tests := []struct {
+		testName       string
+		args           []string
+		failCall       int
+		wantErr        bool
+		wantErrText    string
+		wantCreated    int
+		wantDeleted    []string
+		wantRemaining  int
+		expectedOutput []string
+	}{
+		{
+			testName:      "successful swap",
+			args:          []string{"--with", "bob@example.com", "--mine", "2025-03-04", "--theirs", "2025-03-08", "--yes"},
+			wantCreated:   2,
+			wantRemaining: 2,
+			expectedOutput: []string{
+				"Covered by: Bob Jones <bob@example.com>",
+				"Covered by: John Doe <john@example.com>",
+				"Start: 2025-03-04 09:00 UTC",
+				"Start: 2025-03-08 09:00 UTC",
+				"Successfully swapped shifts with Bob Jones (2 override(s) created)",
+			},
+		},
+		{
+			testName:       "dry run",
+			args:           []string{"--with", "bob@example.com", "--mine", "2025-03-04", "--theirs", "2025-03-08", "--dry-run"},
+			expectedOutput: []string{"Dry run: no overrides were created"},
+		},
+		{
+			testName:      "second half fails and first is rolled back",
+			args:          []string{"--with", "bob@example.com", "--mine", "2025-03-04", "--theirs", "2025-03-08", "--yes"},
+			failCall:      2,
+			wantErr:       true,
+			wantErrText:   "no shifts were swapped",
+			wantCreated:   1,
+			wantDeleted:   []string{"PO001"},
+			wantRemaining: 0,
+			expectedOutput: []string{
+				"Rolling back 1 override(s)",
+				"Rolled back override PO001",
+			},
+		},
+		{
+			testName:    "first half fails",
+			args:        []string{"--with", "bob@example.com", "--mine", "2025-03-04", "--theirs", "2025-03-08", "--yes"},
+			failCall:    1,
+			wantErr:     true,
+			wantErrText: "error creating overrides for your shift",
+		},
+		{
+			testName:    "shift continuing into the day is not swapped",
+			args:        []string{"--with", "bob@example.com", "--mine", "2025-03-05", "--theirs", "2025-03-08", "--yes"},
+			wantErr:     true,
+			wantErrText: "no shifts found for john@example.com starting on 2025-03-05",
+		},
+		{
+			testName:    "swap with yourself",
+			args:        []string{"--with", "john@example.com", "--mine", "2025-03-04", "--theirs", "2025-03-08", "--yes"},
+			wantErr:     true,
+			wantErrText: "cannot swap shifts with yourself",
+		},
+		{
+			testName:    "invalid date",
+			args:        []string{"--with", "bob@example.com", "--mine", "03/04/2025", "--theirs", "2025-03-08"},
+			wantErr:     true,
+			wantErrText: "invalid --mine date",
+		},
+		{
+			testName:    "missing required flags",
+			args:        []string{"--with", "bob@example.com"},
+			wantErr:     true,
+			wantErrText: "--with, --mine, and --theirs are all required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fixture := NewTestFixture()
+			fixture.MockClient.AddUser("USER002", "Bob Jones", "bob@example.com")
+			fixture.MockClient.AddOnCall("USER001", "John Doe", "john@example.com",
+				time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC))
+			fixture.MockClient.AddOnCall("USER002", "Bob Jones", "bob@example.com",
+				time.Date(2025, 3, 8, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 3, 9, 9, 0, 0, 0, time.UTC))
+			fixture.MockClient.SetErrorOnOverridesCall(tt.failCall)
+
+			cmd := NewSwapCommand(fixture.Context)
+			err := cmd.Execute(context.Background(), tt.args)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SwapCommand.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.wantErrText) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantErrText, err.Error())
+			}
+
+			if len(fixture.MockClient.CreateOverridesCalls) != tt.wantCreated {
+				t.Errorf("Expected %d overrides created, got %d", tt.wantCreated, len(fixture.MockClient.CreateOverridesCalls))
+			}
+			if strings.Join(fixture.MockClient.DeleteOverrideCalls, ",") != strings.Join(tt.wantDeleted, ",") {
+				t.Errorf("Expected deletions %v, got %v", tt.wantDeleted, fixture.MockClient.DeleteOverrideCalls)
+			}
+			if remaining := len(fixture.MockClient.overrides["SCHED123"]); remaining != tt.wantRemaining {
+				t.Errorf("Expected %d overrides left on the schedule, got %d", tt.wantRemaining, remaining)
+			}
+
+			if !fixture.ContainsOutput(tt.expectedOutput...) {
+				t.Errorf("Expected output to contain %v, got:\n%s", tt.expectedOutput, fixture.GetOutput())
+			}
+		})
+	}
+}
+
+func TestSwapCommand_OverrideUsers(t *testing.T) {
+	fixture := NewTestFixture()
+	fixture.MockClient.AddUser("USER002", "Bob Jones", "bob@example.com")
+	fixture.MockClient.AddOnCall("USER001", "John Doe", "john@example.com",
+		time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC))
+	fixture.MockClient.AddOnCall("USER002", "Bob Jones", "bob@example.com",
+		time.Date(2025, 3, 8, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 9, 9, 0, 0, 0, time.UTC))
+
+	cmd := NewSwapCommand(fixture.Context)
+	args := []string{"--with", "bob@example.com", "--mine", "2025-03-04", "--theirs", "2025-03-08", "--yes"}
+	if err := cmd.Execute(context.Background(), args); err != nil {
+		t.Fatalf("SwapCommand.Execute() failed: %v", err)
+	}
+
+	calls := fixture.MockClient.CreateOverridesCalls
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 overrides, got %d", len(calls))
+	}
+
+	// Bob covers John's whole shift, John covers Bob's
+	if calls[0].User.ID != "USER002" || !calls[0].Start.Equal(time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC)) ||
+		!calls[0].End.Equal(time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected first override: %+v", calls[0])
+	}
+	if calls[1].User.ID != "USER001" || !calls[1].Start.Equal(time.Date(2025, 3, 8, 9, 0, 0, 0, time.UTC)) ||
+		!calls[1].End.Equal(time.Date(2025, 3, 9, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected second override: %+v", calls[1])
+	}
+}
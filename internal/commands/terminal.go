@@ -0,0 +1,55 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// defaultTerminalWidth is used when the width of the output cannot be determined.
+const defaultTerminalWidth = 80
+
+// isTerminal reports whether file is a terminal (a character device) rather
+// than a regular file or pipe.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// isTerminalWriter reports whether writer writes directly to a terminal.
+func isTerminalWriter(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+	return ok && isTerminal(file)
+}
+
+// terminalWidth returns the width in columns available to writer. $COLUMNS
+// takes precedence, then the size of the terminal writer is attached to, if
+// any, and finally defaultTerminalWidth.
+func terminalWidth(writer io.Writer) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if file, ok := writer.(*os.File); ok {
+		if width, ok := terminalSize(file); ok {
+			return width
+		}
+	}
+	return defaultTerminalWidth
+}
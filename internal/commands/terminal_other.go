@@ -0,0 +1,37 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux && !darwin
+
+package commands
+
+import (
+	"errors"
+	"os"
+)
+
+// terminalSize is not supported on this platform; callers fall back to $COLUMNS
+// or a default width.
+func terminalSize(file *os.File) (int, bool) {
+	return 0, false
+}
+
+// rawModeSupported is false on this platform, so the REPL reads whole lines
+// without its line editor, even on a terminal.
+const rawModeSupported = false
+
+// makeRaw is not supported on this platform; see rawModeSupported.
+func makeRaw(file *os.File) (func() error, error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
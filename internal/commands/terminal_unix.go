@@ -0,0 +1,80 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux || darwin
+
+package commands
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize mirrors struct winsize from <sys/ioctl.h>.
+type winsize struct {
+	rows    uint16
+	columns uint16
+	xpixels uint16
+	ypixels uint16
+}
+
+// terminalSize returns the width of the terminal file is attached to.
+func terminalSize(file *os.File) (int, bool) {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 || ws.columns == 0 {
+		return 0, false
+	}
+	return int(ws.columns), true
+}
+
+// rawModeSupported reports that makeRaw works on this platform, so the REPL
+// can use its line editor.
+const rawModeSupported = true
+
+// makeRaw puts the terminal file is attached to into raw mode, so that keys
+// are read one at a time without echo or signal processing, and returns a
+// function that restores the previous mode. Output processing is left on, so
+// "\n" still starts a new line.
+func makeRaw(file *os.File) (func() error, error) {
+	var original syscall.Termios
+	if err := termiosIoctl(file, ioctlGetTermios, &original); err != nil {
+		return nil, err
+	}
+
+	raw := original
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := termiosIoctl(file, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return termiosIoctl(file, ioctlSetTermios, &original)
+	}, nil
+}
+
+// termiosIoctl gets or sets the terminal attributes of file.
+func termiosIoctl(file *os.File, request uintptr, termios *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), request, uintptr(unsafe.Pointer(termios)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -16,11 +16,14 @@ package commands
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/jdcasey/myshift-go/internal/pagerduty"
 	"github.com/jdcasey/myshift-go/internal/types"
 )
 
@@ -28,10 +31,18 @@ import (
 type MockPagerDutyClient struct {
 	users                  map[string]*types.User
 	shifts                 []types.OnCall
+	schedules              []types.Schedule
 	FindUserByEmailCalls   []string
 	GetUserCalls           []string
+	GetScheduleCalls       []string
 	GetOnCallsCalls        []url.Values
 	CreateOverridesCalls   []types.Override
+	DeleteOverrideCalls    []string
+	overrides              map[string][]types.Override
+	nextOverrideID         int
+	createOverridesCount   int
+	failOverridesCall      int
+	partialOverrides       int
 	shouldErrorOnUser      bool
 	shouldErrorOnOnCalls   bool
 	shouldErrorOnOverrides bool
@@ -46,6 +57,7 @@ func NewMockPagerDutyClient() *MockPagerDutyClient {
 		GetUserCalls:         []string{},
 		GetOnCallsCalls:      []url.Values{},
 		CreateOverridesCalls: []types.Override{},
+		overrides:            make(map[string][]types.Override),
 	}
 }
 
@@ -79,6 +91,36 @@ func (m *MockPagerDutyClient) AddOnCall(userID, userName, userEmail string, star
 	})
 }
 
+// AddOnCallForSchedule adds an on-call shift on a specific schedule to the mock client
+func (m *MockPagerDutyClient) AddOnCallForSchedule(scheduleID, scheduleName, userID, userName, userEmail string, start, end time.Time) {
+	m.AddOnCall(userID, userName, userEmail, start, end)
+	m.shifts[len(m.shifts)-1].Schedule = types.Schedule{
+		ID:       scheduleID,
+		Name:     scheduleName,
+		TimeZone: "UTC",
+	}
+}
+
+// SetLastEscalation sets the escalation policy and level of the most recently added on-call shift
+func (m *MockPagerDutyClient) SetLastEscalation(policyID, policyName string, level int) {
+	last := &m.shifts[len(m.shifts)-1]
+	last.EscalationPolicy = types.EscalationPolicy{ID: policyID, Summary: policyName}
+	last.EscalationLevel = level
+}
+
+// AddSchedule adds a schedule to the mock client
+func (m *MockPagerDutyClient) AddSchedule(id, name, timeZone string, teams ...string) {
+	schedule := types.Schedule{
+		ID:       id,
+		Name:     name,
+		TimeZone: timeZone,
+	}
+	for _, team := range teams {
+		schedule.Teams = append(schedule.Teams, types.Team{ID: "T" + team, Summary: team})
+	}
+	m.schedules = append(m.schedules, schedule)
+}
+
 // SetErrorOnUser makes the mock return an error for user operations
 func (m *MockPagerDutyClient) SetErrorOnUser(shouldError bool) {
 	m.shouldErrorOnUser = shouldError
@@ -94,8 +136,19 @@ func (m *MockPagerDutyClient) SetErrorOnOverrides(shouldError bool) {
 	m.shouldErrorOnOverrides = shouldError
 }
 
+// SetErrorOnOverridesCall makes the nth call (starting at 1) to CreateOverrides fail
+func (m *MockPagerDutyClient) SetErrorOnOverridesCall(n int) {
+	m.failOverridesCall = n
+}
+
+// SetPartialOverrides makes CreateOverrides create only the first n of the
+// overrides it is given, returning them along with an error
+func (m *MockPagerDutyClient) SetPartialOverrides(n int) {
+	m.partialOverrides = n
+}
+
 // FindUserByEmail implements PagerDutyClient interface
-func (m *MockPagerDutyClient) FindUserByEmail(email string) (*types.User, error) {
+func (m *MockPagerDutyClient) FindUserByEmail(ctx context.Context, email string) (*types.User, error) {
 	m.FindUserByEmailCalls = append(m.FindUserByEmailCalls, email)
 
 	if m.shouldErrorOnUser {
@@ -104,14 +157,14 @@ func (m *MockPagerDutyClient) FindUserByEmail(email string) (*types.User, error)
 
 	user, exists := m.users[email]
 	if !exists {
-		return nil, fmt.Errorf("user with email %s not found", email)
+		return nil, fmt.Errorf("user with email %s %w", email, pagerduty.ErrNotFound)
 	}
 
 	return user, nil
 }
 
 // GetUser implements PagerDutyClient interface
-func (m *MockPagerDutyClient) GetUser(userID string) (*types.User, error) {
+func (m *MockPagerDutyClient) GetUser(ctx context.Context, userID string) (*types.User, error) {
 	m.GetUserCalls = append(m.GetUserCalls, userID)
 
 	if m.shouldErrorOnUser {
@@ -128,7 +181,7 @@ func (m *MockPagerDutyClient) GetUser(userID string) (*types.User, error) {
 }
 
 // GetOnCalls implements PagerDutyClient interface
-func (m *MockPagerDutyClient) GetOnCalls(params url.Values) ([]types.OnCall, error) {
+func (m *MockPagerDutyClient) GetOnCalls(ctx context.Context, params url.Values) ([]types.OnCall, error) {
 	m.GetOnCallsCalls = append(m.GetOnCallsCalls, params)
 
 	if m.shouldErrorOnOnCalls {
@@ -139,6 +192,7 @@ func (m *MockPagerDutyClient) GetOnCalls(params url.Values) ([]types.OnCall, err
 	var filteredShifts []types.OnCall
 	userIDs := params["user_ids[]"]
 	scheduleIDs := params["schedule_ids[]"]
+	policyIDs := params["escalation_policy_ids[]"]
 
 	for _, shift := range m.shifts {
 		// Check user filter
@@ -169,6 +223,20 @@ func (m *MockPagerDutyClient) GetOnCalls(params url.Values) ([]types.OnCall, err
 			}
 		}
 
+		// Check escalation policy filter
+		if len(policyIDs) > 0 {
+			policyMatch := false
+			for _, policyID := range policyIDs {
+				if shift.EscalationPolicy.ID == policyID {
+					policyMatch = true
+					break
+				}
+			}
+			if !policyMatch {
+				continue
+			}
+		}
+
 		// Check time range
 		if sinceStr := params.Get("since"); sinceStr != "" {
 			since, err := time.Parse(time.RFC3339, sinceStr)
@@ -184,21 +252,103 @@ func (m *MockPagerDutyClient) GetOnCalls(params url.Values) ([]types.OnCall, err
 			}
 		}
 
+		// Like the API, return only a schedule reference unless full
+		// schedules are included
+		if !slices.Contains(params["include[]"], "schedules") && shift.Schedule.ID != "" {
+			shift.Schedule = types.Schedule{ID: shift.Schedule.ID}
+		}
+
 		filteredShifts = append(filteredShifts, shift)
 	}
 
 	return filteredShifts, nil
 }
 
+// ListSchedules implements PagerDutyClient interface
+func (m *MockPagerDutyClient) ListSchedules(ctx context.Context, query string) ([]types.Schedule, error) {
+	var matches []types.Schedule
+	for _, schedule := range m.schedules {
+		if query == "" || strings.Contains(strings.ToLower(schedule.Name), strings.ToLower(query)) {
+			matches = append(matches, schedule)
+		}
+	}
+	return matches, nil
+}
+
+// GetSchedule implements PagerDutyClient interface
+func (m *MockPagerDutyClient) GetSchedule(ctx context.Context, scheduleID string) (*types.Schedule, error) {
+	m.GetScheduleCalls = append(m.GetScheduleCalls, scheduleID)
+
+	for i := range m.schedules {
+		if m.schedules[i].ID == scheduleID {
+			return &m.schedules[i], nil
+		}
+	}
+	return nil, &pagerduty.APIError{StatusCode: 404, Message: "Not Found"}
+}
+
 // CreateOverrides implements PagerDutyClient interface
-func (m *MockPagerDutyClient) CreateOverrides(scheduleID string, overrides []types.Override) error {
-	if m.shouldErrorOnOverrides {
-		return fmt.Errorf("mock error creating overrides")
+func (m *MockPagerDutyClient) CreateOverrides(ctx context.Context, scheduleID string, overrides []types.Override) ([]types.Override, error) {
+	m.createOverridesCount++
+	if m.shouldErrorOnOverrides || m.createOverridesCount == m.failOverridesCall {
+		return nil, fmt.Errorf("mock error creating overrides")
 	}
 
 	m.CreateOverridesCalls = append(m.CreateOverridesCalls, overrides...)
 
-	return nil
+	var rejected error
+	if m.partialOverrides > 0 && len(overrides) > m.partialOverrides {
+		overrides = overrides[:m.partialOverrides]
+		rejected = fmt.Errorf("mock error: some overrides were rejected")
+	}
+
+	var created []types.Override
+	for _, override := range overrides {
+		m.nextOverrideID++
+		m.AddOverride(scheduleID, fmt.Sprintf("PO%03d", m.nextOverrideID), override.User.ID, override.Start, override.End)
+		existing := m.overrides[scheduleID]
+		created = append(created, existing[len(existing)-1])
+	}
+
+	return created, rejected
+}
+
+// AddOverride adds an existing override to the mock client
+func (m *MockPagerDutyClient) AddOverride(scheduleID, overrideID, userID string, start, end time.Time) {
+	m.overrides[scheduleID] = append(m.overrides[scheduleID], types.Override{
+		ID:    overrideID,
+		Start: start,
+		End:   end,
+		User: types.UserReference{
+			ID:   userID,
+			Type: "user_reference",
+		},
+	})
+}
+
+// ListOverrides implements PagerDutyClient interface
+func (m *MockPagerDutyClient) ListOverrides(ctx context.Context, scheduleID string, since, until time.Time) ([]types.Override, error) {
+	var matches []types.Override
+	for _, override := range m.overrides[scheduleID] {
+		if override.End.After(since) && override.Start.Before(until) {
+			matches = append(matches, override)
+		}
+	}
+	return matches, nil
+}
+
+// DeleteOverride implements PagerDutyClient interface
+func (m *MockPagerDutyClient) DeleteOverride(ctx context.Context, scheduleID, overrideID string) error {
+	m.DeleteOverrideCalls = append(m.DeleteOverrideCalls, overrideID)
+
+	existing := m.overrides[scheduleID]
+	for i, override := range existing {
+		if override.ID == overrideID {
+			m.overrides[scheduleID] = append(existing[:i:i], existing[i+1:]...)
+			return nil
+		}
+	}
+	return &pagerduty.APIError{StatusCode: 404, Message: "Not Found"}
 }
 
 // TestFixture provides a complete test setup
@@ -217,10 +367,12 @@ func NewTestFixture() *TestFixture {
 		PagerDutyToken: "test-token",
 		ScheduleID:     "SCHED123",
 		MyUser:         "john@example.com",
+		TimeZone:       "UTC",
 	}
 
 	buffer := &bytes.Buffer{}
-	context := NewCommandContext(mockClient, config, buffer)
+	cmdContext := NewCommandContext(mockClient, config, buffer)
+	cmdContext.Reader = strings.NewReader("")
 
 	// Add default test user
 	mockClient.AddUser("USER001", "John Doe", "john@example.com")
@@ -228,7 +380,7 @@ func NewTestFixture() *TestFixture {
 	return &TestFixture{
 		MockClient: mockClient,
 		Config:     config,
-		Context:    context,
+		Context:    cmdContext,
 		Buffer:     buffer,
 		Now:        time.Now(),
 	}
@@ -16,25 +16,268 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
 )
 
-// CalculateTimeRange calculates start and end times from string inputs and days
-func CalculateTimeRange(startDate, endDate string, days int) (time.Time, time.Time, error) {
-	var start, end time.Time
+// ScheduleTimeZone is the --tz and time_zone value that selects the time zone
+// of the schedule being shown instead of a fixed zone.
+const ScheduleTimeZone = "schedule"
+
+// LoadTimeZone loads a time zone by IANA name, such as "Europe/Berlin". An
+// empty name or "local" loads the local zone, by its IANA name when that can
+// be determined so that it can be sent to PagerDuty.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "local") {
+		if zone := localZoneName(); zone != "" {
+			if loc, err := time.LoadLocation(zone); err == nil {
+				return loc, nil
+			}
+		}
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q (expected an IANA name such as Europe/Berlin, or local)", name)
+	}
+	return loc, nil
+}
+
+// localZoneName returns the IANA name of the local time zone from $TZ or the
+// /etc/localtime link, or "" if it cannot be determined.
+func localZoneName() string {
+	if zone, ok := os.LookupEnv("TZ"); ok {
+		return strings.TrimPrefix(zone, ":")
+	}
+	target, err := filepath.EvalSymlinks("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	if _, zone, found := strings.Cut(filepath.ToSlash(target), "/zoneinfo/"); found {
+		return zone
+	}
+	return ""
+}
+
+// timeZoneName returns the name of loc to send to PagerDuty, which needs an
+// IANA name rather than Go's "Local". The local zone is sent by its IANA name
+// when that can be determined, and as UTC otherwise.
+func timeZoneName(loc *time.Location) string {
+	if loc != time.Local {
+		return loc.String()
+	}
+	if zone := localZoneName(); zone != "" {
+		if _, err := time.LoadLocation(zone); err == nil {
+			return zone
+		}
+	}
+	return "UTC"
+}
+
+// ShiftsIn returns a copy of shifts with their start and end times in loc, so
+// that every formatter shows them in that zone. Zero times stay zero.
+func ShiftsIn(shifts []types.OnCall, loc *time.Location) []types.OnCall {
+	converted := make([]types.OnCall, len(shifts))
+	for i, shift := range shifts {
+		if !shift.Start.IsZero() {
+			shift.Start = shift.Start.In(loc)
+		}
+		if !shift.End.IsZero() {
+			shift.End = shift.End.In(loc)
+		}
+		converted[i] = shift
+	}
+	return converted
+}
+
+// dateExpressionHint lists examples of the input ParseDateExpression accepts,
+// for error messages.
+const dateExpressionHint = "try YYYY-MM-DD [HH:MM], ISO 8601, today, 'tomorrow 09:00', 'next monday', +3d, +2w or 'end of month'"
+
+// Layouts accepted for absolute dates, in the time zone of now unless they
+// carry an offset.
+var (
+	dateLayouts     = []string{"2006-01-02"}
+	dateTimeLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+	offsetLayouts   = []string{time.RFC3339, "2006-01-02T15:04Z07:00", "2006-01-02T15:04:05Z0700", "2006-01-02T15:04Z0700"}
+)
+
+// ambiguousDate matches dates like 01/02/2025, which read differently in the
+// US and elsewhere.
+var ambiguousDate = regexp.MustCompile(`^\d{1,2}[/.]\d{1,2}[/.]\d{2,4}$`)
+
+// relativeOffset matches offsets like +3d, -12h or +2w.
+var relativeOffset = regexp.MustCompile(`^([+-])(\d+)([a-z]+)$`)
+
+// ParseDateExpression parses a date or time typed on the command line,
+// relative to now and in now's time zone. It accepts:
+//
+//   - absolute dates and times: 2025-01-15, 2025-01-15 09:00, and ISO 8601
+//     with an offset such as 2025-01-15T09:00:00Z or 2025-01-15T09:00+05:30
+//   - now, and the days today, tomorrow and yesterday
+//   - next <weekday>: the first such day after today
+//   - offsets from now: +6h, +3d, +2w, -1d
+//   - start of week, end of week, start of month and end of month, where an end
+//     is the first moment after the period, since ranges exclude their end
+//
+// Days start at midnight unless followed by a time, as in "tomorrow 09:00" or
+// "next monday 14:30". Input that could mean several things, such as a bare
+// weekday or 01/02/2025, is rejected with an explanation.
+func ParseDateExpression(value string, now time.Time) (time.Time, error) {
+	expr := strings.ToLower(strings.Join(strings.Fields(value), " "))
+	if expr == "" {
+		return time.Time{}, fmt.Errorf("empty date (%s)", dateExpressionHint)
+	}
+
+	// Layouts use upper case T and Z
+	upper := strings.ToUpper(expr)
+	for _, layout := range offsetLayouts {
+		if t, err := time.Parse(layout, upper); err == nil {
+			return t.In(now.Location()), nil
+		}
+	}
+	for _, layout := range append(dateLayouts, dateTimeLayouts...) {
+		if t, err := time.ParseInLocation(layout, upper, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	if ambiguousDate.MatchString(expr) {
+		return time.Time{}, fmt.Errorf("ambiguous date %q: day and month order differ between regions, use YYYY-MM-DD", value)
+	}
+
+	switch expr {
+	case "now":
+		return now, nil
+	case "start of week":
+		return weekStart(now), nil
+	case "end of week":
+		return weekStart(now).AddDate(0, 0, 7), nil
+	case "start of month":
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), nil
+	case "end of month":
+		return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location()), nil
+	}
+
+	if match := relativeOffset.FindStringSubmatch(expr); match != nil {
+		return parseRelativeOffset(value, match, now)
+	}
+
+	// A day, optionally followed by a time of day
+	dayExpr, clock := expr, ""
+	if fields := strings.Fields(expr); len(fields) > 1 && strings.Contains(fields[len(fields)-1], ":") {
+		dayExpr, clock = strings.Join(fields[:len(fields)-1], " "), fields[len(fields)-1]
+	}
+
+	day, err := parseDay(value, dayExpr, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if clock == "" {
+		return day, nil
+	}
+
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q in %q: expected HH:MM", clock, value)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
+}
+
+// parseRelativeOffset applies an offset such as +3d, matched by relativeOffset,
+// to now. Days and weeks keep the time of day across daylight saving changes.
+func parseRelativeOffset(value string, match []string, now time.Time) (time.Time, error) {
+	n, err := strconv.Atoi(match[2])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid offset %q: %w", value, err)
+	}
+	if match[1] == "-" {
+		n = -n
+	}
+
+	switch match[3] {
+	case "h":
+		return now.Add(time.Duration(n) * time.Hour), nil
+	case "d":
+		return now.AddDate(0, 0, n), nil
+	case "w":
+		return now.AddDate(0, 0, 7*n), nil
+	case "m":
+		return time.Time{}, fmt.Errorf("ambiguous unit in %q: m could mean minutes or months, use h, d or w", value)
+	default:
+		return time.Time{}, fmt.Errorf("unknown unit %q in %q: use h (hours), d (days) or w (weeks)", match[3], value)
+	}
+}
+
+// parseDay parses a day expression to midnight of that day.
+func parseDay(value, expr string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch expr {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, expr, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	name, isNext := strings.CutPrefix(expr, "next ")
+	if weekday, ok := parseWeekday(name); ok {
+		if !isNext {
+			return time.Time{}, fmt.Errorf("ambiguous date %q: use \"next %s\" or a date (YYYY-MM-DD)", value, name)
+		}
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q (%s)", value, dateExpressionHint)
+}
+
+// parseWeekday parses a weekday name or its three-letter abbreviation.
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// CalculateTimeRange calculates start and end times from date expressions
+// (see ParseDateExpression) and days, in loc. The range defaults to starting
+// now and lasting days.
+func CalculateTimeRange(startDate, endDate string, days int, loc *time.Location) (time.Time, time.Time, error) {
+	now := time.Now().In(loc)
+	start, end := now, time.Time{}
 	var err error
 
 	if startDate != "" {
-		start, err = time.Parse("2006-01-02", startDate)
+		start, err = ParseDateExpression(startDate, now)
 		if err != nil {
 			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %w", err)
 		}
-	} else {
-		start = time.Now()
 	}
 
 	if endDate != "" {
-		end, err = time.Parse("2006-01-02", endDate)
+		end, err = ParseDateExpression(endDate, now)
 		if err != nil {
 			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %w", err)
 		}
@@ -42,21 +285,39 @@ func CalculateTimeRange(startDate, endDate string, days int) (time.Time, time.Ti
 		end = start.AddDate(0, 0, days)
 	}
 
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end (%s) must be after start (%s)",
+			end.Format("2006-01-02 15:04 MST"), start.Format("2006-01-02 15:04 MST"))
+	}
+
 	return start, end, nil
 }
 
-// ParseTimeRange parses start and end times with specific format
-func ParseTimeRange(startTime, endTime string) (time.Time, time.Time, error) {
-	start, err := time.Parse("2006-01-02 15:04", startTime)
+// ParseTimeRange parses start and end date expressions (see
+// ParseDateExpression) as times in loc. An offset end, such as +8h, counts
+// from the start rather than from now.
+func ParseTimeRange(startTime, endTime string, loc *time.Location) (time.Time, time.Time, error) {
+	now := time.Now().In(loc)
+
+	start, err := ParseDateExpression(startTime, now)
 	if err != nil {
 		return time.Time{}, time.Time{}, fmt.Errorf("invalid start time: %w", err)
 	}
 
-	end, err := time.Parse("2006-01-02 15:04", endTime)
+	endBase := now
+	if relativeOffset.MatchString(strings.ToLower(strings.TrimSpace(endTime))) {
+		endBase = start
+	}
+	end, err := ParseDateExpression(endTime, endBase)
 	if err != nil {
 		return time.Time{}, time.Time{}, fmt.Errorf("invalid end time: %w", err)
 	}
 
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end time (%s) must be after start time (%s)",
+			end.Format("2006-01-02 15:04 MST"), start.Format("2006-01-02 15:04 MST"))
+	}
+
 	return start, end, nil
 }
 
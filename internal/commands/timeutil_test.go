@@ -0,0 +1,175 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDateExpression(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() failed: %v", err)
+	}
+	// A Wednesday afternoon
+	now := time.Date(2025, 1, 15, 14, 30, 0, 0, loc)
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr string
+	}{
+		{value: "2025-02-03", want: "2025-02-03 00:00 EST"},
+		{value: "2025-02-03 09:15", want: "2025-02-03 09:15 EST"},
+		{value: "2025-07-03T09:15", want: "2025-07-03 09:15 EDT"},
+		{value: "2025-02-03T09:15:00Z", want: "2025-02-03 04:15 EST"},
+		{value: "2025-02-03T20:00:00+05:30", want: "2025-02-03 09:30 EST"},
+		{value: "now", want: "2025-01-15 14:30 EST"},
+		{value: "Today", want: "2025-01-15 00:00 EST"},
+		{value: "tomorrow 09:00", want: "2025-01-16 09:00 EST"},
+		{value: "yesterday", want: "2025-01-14 00:00 EST"},
+		{value: "next monday", want: "2025-01-20 00:00 EST"},
+		{value: "next wed 17:45", want: "2025-01-22 17:45 EST"},
+		{value: "+3d", want: "2025-01-18 14:30 EST"},
+		{value: "+2w", want: "2025-01-29 14:30 EST"},
+		{value: "-6h", want: "2025-01-15 08:30 EST"},
+		{value: "start of week", want: "2025-01-13 00:00 EST"},
+		{value: "end of week", want: "2025-01-20 00:00 EST"},
+		{value: "start of month", want: "2025-01-01 00:00 EST"},
+		{value: "end  of   month", want: "2025-02-01 00:00 EST"},
+		{value: "", wantErr: "empty date"},
+		{value: "monday", wantErr: `use "next monday"`},
+		{value: "01/02/2025", wantErr: "ambiguous date"},
+		{value: "+3m", wantErr: "minutes or months"},
+		{value: "+3y", wantErr: "unknown unit"},
+		{value: "tomorrow 25:00", wantErr: "expected HH:MM"},
+		{value: "someday", wantErr: "invalid date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDateExpression(tt.value, now)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseDateExpression(%q) error = %v, want error containing %q", tt.value, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDateExpression(%q) failed: %v", tt.value, err)
+			}
+			if got.Format("2006-01-02 15:04 MST") != tt.want {
+				t.Errorf("ParseDateExpression(%q) = %s, want %s", tt.value, got.Format("2006-01-02 15:04 MST"), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateExpression_DaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() failed: %v", err)
+	}
+	// The day before clocks go forward on 2025-03-09
+	now := time.Date(2025, 3, 8, 9, 0, 0, 0, loc)
+
+	got, err := ParseDateExpression("+1d", now)
+	if err != nil {
+		t.Fatalf("ParseDateExpression() failed: %v", err)
+	}
+	if got.Format("2006-01-02 15:04 MST") != "2025-03-09 09:00 EDT" {
+		t.Errorf("Expected +1d to keep the time of day, got %s", got.Format("2006-01-02 15:04 MST"))
+	}
+	if elapsed := got.Sub(now); elapsed != 23*time.Hour {
+		t.Errorf("Expected 23 hours to pass, got %s", elapsed)
+	}
+}
+
+func TestCalculateTimeRange_EndBeforeStart(t *testing.T) {
+	_, _, err := CalculateTimeRange("2025-02-01", "2025-01-01", 0, time.UTC)
+	if err == nil || !strings.Contains(err.Error(), "must be after start") {
+		t.Errorf("Expected an error for an end before the start, got %v", err)
+	}
+}
+
+func TestParseTimeRange_DaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("LoadLocation() failed: %v", err)
+	}
+
+	// British Summer Time starts at 01:00 UTC on 2024-03-31
+	start, end, err := ParseTimeRange("2024-03-30 09:00", "2024-03-31 09:00", loc)
+	if err != nil {
+		t.Fatalf("ParseTimeRange() failed: %v", err)
+	}
+	if got := end.Sub(start); got != 23*time.Hour {
+		t.Errorf("Expected a 23 hour range across the change, got %s", got)
+	}
+	if want := time.Date(2024, 3, 31, 8, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("Expected end %s, got %s", want, end.UTC())
+	}
+
+	// A range given in days keeps midnight in the zone across the change
+	start, end, err = CalculateTimeRange("2024-03-30", "", 2, loc)
+	if err != nil {
+		t.Fatalf("CalculateTimeRange() failed: %v", err)
+	}
+	if got := end.Format("2006-01-02 15:04 MST"); got != "2024-04-01 00:00 BST" {
+		t.Errorf("Expected end at midnight BST, got %s", got)
+	}
+	if got := end.Sub(start); got != 47*time.Hour {
+		t.Errorf("Expected a 47 hour range, got %s", got)
+	}
+}
+
+func TestParseTimeRange_OffsetEnd(t *testing.T) {
+	start, end, err := ParseTimeRange("2025-01-15 09:00", "+8h", time.UTC)
+	if err != nil {
+		t.Fatalf("ParseTimeRange() failed: %v", err)
+	}
+	if want := time.Date(2025, 1, 15, 17, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("Expected end %s, 8 hours after the start %s, got %s", want, start, end)
+	}
+}
+
+func TestTimeZoneName(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("LoadLocation() failed: %v", err)
+	}
+
+	tests := []struct {
+		testName string
+		tz       string
+		loc      *time.Location
+		want     string
+	}{
+		{"named zone", "", berlin, "Europe/Berlin"},
+		{"local zone by name", "America/New_York", time.Local, "America/New_York"},
+		{"unknown local zone", "Nowhere/Special", time.Local, "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("TZ", tt.tz)
+			if got := timeZoneName(tt.loc); got != tt.want {
+				t.Errorf("timeZoneName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
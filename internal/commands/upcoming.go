@@ -16,6 +16,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -33,18 +34,31 @@ func NewUpcomingCommand(ctx *CommandContext) *UpcomingCommand {
 }
 
 // Execute runs the upcoming command to show all upcoming shifts for a user.
-func (u *UpcomingCommand) Execute(args []string) error {
+func (u *UpcomingCommand) Execute(ctx context.Context, args []string) error {
 	parser := NewFlagParser("upcoming").
 		AddUserFlag("", "User email address (uses my_user from config if not provided)").
 		AddDaysFlag(28, "Number of days to look ahead").
-		AddFormatFlag("text", "Output format (text, ical)").
+		AddScheduleFlag("Schedule alias or ID; repeat to combine schedules (uses the default schedule if not provided)").
+		AddFormatFlag("text", "Output format (text, ical, json, csv, tsv, markdown, html, template, calendar)").
+		AddColumnsFlag("Columns for csv and tsv output (default: start,end,user,email,schedule,hours)").
+		AddTemplateFlag("Template text, or the name of a template in the config, for template output").
+		AddAlarmFlag("Add a reminder this long before each shift for ical output, e.g. 1h,15m; repeatable").
+		AddTimeZoneFlag("Time zone for dates and times: an IANA name, local, or schedule (default: time_zone from config, else local)").
 		SetUsage(func() {
 			fmt.Print(`Usage: myshift upcoming [options]
 
 Options:
   --user string        User email address (uses my_user from config if not provided)
   --days int           Number of days to look ahead (default: 28)
-  --format, -o string  Output format: text, ical (default: text)
+  --schedule string    Schedule alias or ID; repeat to combine schedules
+  --format, -o string  Output format: text, ical, json, csv, tsv, markdown, html, template,
+                       calendar (default: text)
+  --columns string     Columns for csv and tsv output (default: start,end,user,email,schedule,hours)
+  --template string    Template text, or the name of a template in the config, for template output
+  --alarm string       Add a reminder this long before each shift for ical output,
+                       e.g. 1h,15m; repeatable
+  --tz string          Time zone: an IANA name (e.g. Europe/Berlin), local, or schedule
+                       (default: time_zone from config, else local)
 
 `)
 		})
@@ -59,27 +73,34 @@ Options:
 		return nil
 	}
 
-	// Get schedule ID
-	scheduleID, err := u.GetScheduleID()
+	// Resolve schedule aliases to IDs
+	scheduleIDs, err := u.ResolveScheduleIDs(flags.Schedules)
 	if err != nil {
 		return err
 	}
 
 	// Find user by email
-	user, err := u.ResolveUser(flags.User)
+	user, err := u.ResolveUser(ctx, flags.User)
+	if err != nil {
+		return err
+	}
+
+	// Shifts are shown in the chosen time zone
+	loc, err := u.Location(ctx, flags.TimeZone, scheduleIDs[0])
 	if err != nil {
 		return err
 	}
 
 	// Calculate time range
-	now := time.Now()
+	now := time.Now().In(loc)
 	until := now.AddDate(0, 0, flags.Days)
 
 	// Get on-call shifts
-	onCalls, err := u.GetOnCallsForUser(scheduleID, user.ID, now, until)
+	onCalls, err := u.GetOnCallsForUser(ctx, scheduleIDs, user.ID, now, until)
 	if err != nil {
 		return err
 	}
+	onCalls = ShiftsIn(onCalls, loc)
 
 	// Create user map with the user we already fetched
 	userMap := map[string]string{
@@ -87,7 +108,7 @@ Options:
 	}
 
 	// Get the appropriate formatter
-	formatter, err := GetFormatter(flags.Format)
+	formatter, err := GetFormatter(flags.Format, u.FormatOptions(flags, loc))
 	if err != nil {
 		return err
 	}
@@ -103,7 +124,15 @@ func (u *UpcomingCommand) Usage() string {
 Options:
   --user string        User email address (uses my_user from config if not provided)
   --days int           Number of days to look ahead (default: 28)
-  --format, -o string  Output format: text, ical (default: text)
+  --schedule string    Schedule alias or ID; repeat to combine schedules
+  --format, -o string  Output format: text, ical, json, csv, tsv, markdown, html, template,
+                       calendar (default: text)
+  --columns string     Columns for csv and tsv output (default: start,end,user,email,schedule,hours)
+  --template string    Template text, or the name of a template in the config, for template output
+  --alarm string       Add a reminder this long before each shift for ical output,
+                       e.g. 1h,15m; repeatable
+  --tz string          Time zone: an IANA name (e.g. Europe/Berlin), local, or schedule
+                       (default: time_zone from config, else local)
 
 `
 }
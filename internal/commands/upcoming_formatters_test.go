@@ -15,6 +15,7 @@
 package commands
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -50,7 +51,7 @@ func TestUpcomingCommand_Formatters(t *testing.T) {
 			cmd := NewUpcomingCommand(fixture.Context)
 
 			// Execute
-			err := cmd.Execute(tt.args)
+			err := cmd.Execute(context.Background(), tt.args)
 
 			// Verify error expectation
 			if (err != nil) != tt.wantErr {
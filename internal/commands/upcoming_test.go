@@ -15,6 +15,7 @@
 package commands
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -62,7 +63,7 @@ func TestUpcomingCommand_Execute(t *testing.T) {
 			cmd := NewUpcomingCommand(fixture.Context)
 
 			// Execute
-			err := cmd.Execute(tt.args)
+			err := cmd.Execute(context.Background(), tt.args)
 
 			// Verify error expectation
 			if (err != nil) != tt.wantErr {
@@ -87,6 +88,6 @@ func BenchmarkUpcomingCommand_Execute(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		fixture.ClearOutput()
-		_ = cmd.Execute([]string{"--user", "john@example.com", "--days", "7"})
+		_ = cmd.Execute(context.Background(), []string{"--user", "john@example.com", "--days", "7"})
 	}
 }
@@ -24,7 +24,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/jdcasey/myshift-go/internal/types"
 	"gopkg.in/yaml.v3"
@@ -66,42 +68,127 @@ func getConfigPaths() []string {
 // configPathsFunc is a variable that can be overridden for testing
 var configPathsFunc = getConfigPaths
 
+// ProfileEnv is the environment variable naming the profile to use when none
+// is given with --profile.
+const ProfileEnv = "MYSHIFT_PROFILE"
+
 // Load attempts to load configuration from the first available config file
 // in the standard platform-specific locations. It searches for YAML configuration
 // files in order of precedence and loads the first one found.
 //
 // The configuration file should be a YAML file containing:
-//   - pagerduty_token: Required API token for PagerDuty (string)
+//   - pagerduty_token: Required API token for PagerDuty (string), unless one of
+//     pagerduty_token_command, pagerduty_token_file or pagerduty_token_keyring
+//     gives it instead
 //   - my_user: Optional user ID or email for the current user (string)
 //   - schedule_id: Optional default schedule ID (string)
+//   - schedules: Optional map of schedule aliases to schedule IDs
+//   - default_schedule: Optional alias or ID of the default schedule (string)
+//   - templates: Optional map of names to output templates for --format template
+//   - time_zone: Optional time zone for dates and times: an IANA name, "local" or "schedule"
+//   - profiles: Optional map of profile names to any of the settings above
+//   - default_profile: Optional name of the profile used when none is selected
+//
+// The profile named by $MYSHIFT_PROFILE is selected, or else default_profile;
+// see LoadProfile.
 //
 // Search locations (in order):
 //   - Linux: $XDG_CONFIG_HOME/myshift.yaml or ~/.config/myshift.yaml
 //   - macOS: ~/Library/Application Support/myshift.yaml
 //
+// Settings given in MYSHIFT_* environment variables take precedence over the
+// file; see LoadWithOptions.
+//
 // Returns a validated Config object or an error if no config file is found
-// or if the configuration is invalid.
+// and the environment gives no PagerDuty token, or if the configuration is
+// invalid.
 func Load() (*types.Config, error) {
+	return LoadWithOptions(Options{})
+}
+
+// LoadProfile loads configuration like Load, selecting the named profile. An
+// empty name selects the profile named by $MYSHIFT_PROFILE, or else the
+// file's default_profile. Without any of these, the top-level settings are
+// used. Top-level settings also apply to every profile that does not set
+// them, so settings shared by all accounts, such as templates, can be given
+// once.
+func LoadProfile(profile string) (*types.Config, error) {
+	return LoadWithOptions(Options{Profile: profile})
+}
+
+// FindConfigFile returns the path of the configuration file that Load would use,
+// or an error if no configuration file exists in any of the standard locations.
+func FindConfigFile() (string, error) {
 	for _, path := range configPathsFunc() {
 		if _, err := os.Stat(path); err == nil {
-			return loadFromFile(path)
+			return path, nil
 		}
 	}
 
-	return nil, fmt.Errorf("no configuration file found. Please create one using 'myshift config --print'")
+	return "", fmt.Errorf("no configuration file found. Please create one using 'myshift config --print'")
 }
 
 // loadFromFile loads and validates configuration from a specific file path.
-// It reads the YAML file, unmarshals it into a Config struct, and validates
-// that all required fields are present and valid.
-// The function validates the file path to prevent directory traversal attacks.
+// It reads the YAML file, selects a profile, applies the defaults,
+// environment variables and flags around it as described for
+// LoadWithOptions, and validates that all required fields of the result are
+// present and valid.
 //
 // Parameters:
-//   - path: The file system path to the YAML configuration file
+//   - path: The file system path to the YAML configuration file, or "" for none
+//   - opts: The profile to select and the settings given as flags
 //
 // Returns a validated Config object or an error if the file cannot be read,
 // parsed, or if validation fails.
-func loadFromFile(path string) (*types.Config, error) {
+func loadFromFile(path string, opts Options) (*types.Config, error) {
+	config := &types.Config{}
+	if path != "" {
+		file, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if config, err = selectProfile(file, opts.Profile); err != nil {
+			return nil, fmt.Errorf("invalid configuration in %s: %w", path, err)
+		}
+		config.Sources = fileSources(file, config)
+	} else {
+		profile := opts.Profile
+		if profile == "" {
+			profile = os.Getenv(ProfileEnv)
+		}
+		if profile != "" {
+			return nil, fmt.Errorf("no configuration file found for profile '%s'. Please create one using 'myshift config --print'", profile)
+		}
+		config.Sources = make(map[string]string)
+	}
+
+	applyOverrides(config, opts.Flags)
+
+	if err := resolveToken(config, filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("error reading PagerDuty token: %w", err)
+	}
+
+	if path == "" {
+		if config.PagerDutyToken == "" {
+			return nil, fmt.Errorf("no configuration file found and %s is not set. Please create one using 'myshift config --print'", EnvVar("pagerduty_token"))
+		}
+		if err := validate(config); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %w", err)
+		}
+		return config, nil
+	}
+
+	if err := validate(config); err != nil {
+		return nil, fmt.Errorf("invalid configuration in %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// readFile reads and parses the configuration file at path without
+// validating it. The function validates the file path to prevent directory
+// traversal attacks.
+func readFile(path string) (*types.Config, error) {
 	// Validate and clean the file path to prevent directory traversal attacks
 	cleanPath := filepath.Clean(path)
 	if cleanPath != path {
@@ -123,11 +210,87 @@ func loadFromFile(path string) (*types.Config, error) {
 		return nil, fmt.Errorf("error parsing config file %s: %w", cleanPath, err)
 	}
 
-	if err := validate(&config); err != nil {
-		return nil, fmt.Errorf("invalid configuration in %s: %w", cleanPath, err)
+	return &config, nil
+}
+
+// selectProfile returns the settings of the named profile in file, merged
+// over the top-level settings. An empty name falls back to $MYSHIFT_PROFILE
+// and then default_profile; with neither, the top-level settings are
+// returned as they are. The result must give the PagerDuty token in at most
+// one way.
+func selectProfile(file *types.Config, name string) (*types.Config, error) {
+	if name == "" {
+		name = os.Getenv(ProfileEnv)
+	}
+	if name == "" {
+		name = file.DefaultProfile
+	}
+	if name == "" {
+		return file, checkTokenSettings(file)
+	}
+
+	profile, ok := file.Profiles[name]
+	if !ok || profile == nil {
+		if len(file.Profiles) == 0 {
+			return nil, fmt.Errorf("unknown profile '%s' (no profiles are configured)", name)
+		}
+		return nil, fmt.Errorf("unknown profile '%s' (profiles: %s)", name, strings.Join(profileNames(file), ", "))
+	}
+	if len(profile.Profiles) > 0 || profile.DefaultProfile != "" {
+		return nil, fmt.Errorf("profile '%s' cannot contain profiles or default_profile", name)
 	}
 
-	return &config, nil
+	merged := mergeProfile(file, name, profile)
+	if err := checkTokenSettings(merged); err != nil {
+		return nil, fmt.Errorf("profile '%s': %w", name, err)
+	}
+	return merged, nil
+}
+
+// mergeProfile returns the top-level settings of file with those set in
+// profile replacing them. Maps are replaced as a whole, not merged, and a
+// profile giving the token in any way replaces every top-level token setting.
+func mergeProfile(file *types.Config, name string, profile *types.Config) *types.Config {
+	merged := *file
+	merged.Profiles = nil
+	merged.DefaultProfile = ""
+	merged.Profile = name
+
+	if len(tokenSettings(profile)) > 0 {
+		merged.PagerDutyToken = profile.PagerDutyToken
+		merged.PagerDutyTokenCommand = profile.PagerDutyTokenCommand
+		merged.PagerDutyTokenFile = profile.PagerDutyTokenFile
+		merged.PagerDutyTokenKeyring = profile.PagerDutyTokenKeyring
+	}
+	if profile.ScheduleID != "" {
+		merged.ScheduleID = profile.ScheduleID
+	}
+	if profile.Schedules != nil {
+		merged.Schedules = profile.Schedules
+	}
+	if profile.DefaultSchedule != "" {
+		merged.DefaultSchedule = profile.DefaultSchedule
+	}
+	if profile.MyUser != "" {
+		merged.MyUser = profile.MyUser
+	}
+	if profile.Templates != nil {
+		merged.Templates = profile.Templates
+	}
+	if profile.TimeZone != "" {
+		merged.TimeZone = profile.TimeZone
+	}
+	return &merged
+}
+
+// profileNames returns the names of the profiles in file, in order.
+func profileNames(file *types.Config) []string {
+	names := make([]string, 0, len(file.Profiles))
+	for name := range file.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // validate performs validation checks on a loaded configuration object.
@@ -135,15 +298,46 @@ func loadFromFile(path string) (*types.Config, error) {
 // optional fields conform to expected formats when provided.
 //
 // Currently validates:
-//   - pagerduty_token: Must be present and non-empty
+//   - pagerduty_token: Must be present and non-empty, or given by one of its
+//     alternatives, in the profile if one is selected
+//   - schedules: Every alias must map to a non-empty schedule ID
+//   - templates: Every named template must have a non-empty body
+//   - time_zone: Must be an IANA time zone name, "local" or "schedule"
 //
 // Parameters:
 //   - config: The Config object to validate
 //
 // Returns nil if validation passes, or an error describing the validation failure.
 func validate(config *types.Config) error {
-	if config.PagerDutyToken == "" {
-		return fmt.Errorf("'pagerduty_token' is required in configuration")
+	if len(tokenSettings(config)) == 0 {
+		if len(config.Profiles) > 0 {
+			return fmt.Errorf("no profile selected and no top-level 'pagerduty_token' (use --profile, %s or default_profile; profiles: %s)",
+				ProfileEnv, strings.Join(profileNames(config), ", "))
+		}
+		if config.Profile != "" {
+			return fmt.Errorf("'pagerduty_token' is required in profile '%s'", config.Profile)
+		}
+		return fmt.Errorf("'pagerduty_token' is required in configuration (or pagerduty_token_command, pagerduty_token_file or pagerduty_token_keyring)")
+	}
+
+	for alias, id := range config.Schedules {
+		if id == "" {
+			return fmt.Errorf("schedule alias '%s' has no schedule ID", alias)
+		}
+	}
+
+	for name, body := range config.Templates {
+		if body == "" {
+			return fmt.Errorf("template '%s' is empty", name)
+		}
+	}
+
+	switch strings.ToLower(config.TimeZone) {
+	case "", "local", "schedule":
+	default:
+		if _, err := time.LoadLocation(config.TimeZone); err != nil {
+			return fmt.Errorf("'time_zone' %q is not a known time zone (use an IANA name such as Europe/Berlin, local or schedule)", config.TimeZone)
+		}
 	}
 
 	return nil
@@ -163,16 +357,68 @@ func PrintSample() {
 # This file should be placed in one of the following locations:
 # - Linux: ~/.config/myshift.yaml
 # - macOS: ~/Library/Application Support/myshift.yaml
+#
+# pagerduty_token, schedule_id, default_schedule, my_user and time_zone can
+# also be given as MYSHIFT_* environment variables (e.g. MYSHIFT_MY_USER) or
+# global flags (e.g. --my-user), which take precedence over this file.
 
 # PagerDuty API token (required)
 pagerduty_token: "your-pagerduty-token"
 
+# Instead of pagerduty_token, the token can be read from the first line
+# printed by a command, from the first line of a file, or from the system
+# keyring (Secret Service or macOS keychain) under service "myshift" and the
+# given account. Use only one of these.
+# pagerduty_token_command: "pass show pagerduty"
+# pagerduty_token_file: "~/.config/myshift/token"
+# pagerduty_token_keyring: "work"
+
 # Default schedule ID (optional)
 # schedule_id: "your-default-schedule-id"
 
+# Named schedule aliases (optional)
+# Use these with --schedule <alias>; repeat --schedule to combine schedules
+# schedules:
+#   primary: "PXXXXXX"
+#   secondary: "PYYYYYY"
+#   db: "PZZZZZZ"
+
+# Alias or ID used when --schedule is not given (optional)
+# default_schedule: "primary"
+
 # Your PagerDuty user ID or email (optional)
 # This will be used when no --user or --user-email is provided
 # my_user: "your-email@example.com"  # or "your-user-id"
+
+# Time zone for reading and showing dates and times (optional)
+# An IANA name such as "Europe/Berlin", "local" (the default) or "schedule"
+# to use the time zone of the schedule being shown; --tz overrides it
+# time_zone: "America/New_York"
+
+# Named output templates (optional)
+# Use these with --format template --template <name>
+# templates:
+#   slack: |
+#     {{range .Shifts}}• {{userName .}}: {{date "Mon Jan 2 15:04" .Start}} ({{duration .Start .End}})
+#     {{end}}
+
+# Profiles for several PagerDuty accounts (optional)
+# Select one with --profile <name> or MYSHIFT_PROFILE, or set default_profile.
+# A profile may set any of the settings above; top-level settings apply to
+# every profile that does not set them.
+# default_profile: "acme"
+# profiles:
+#   acme:
+#     pagerduty_token: "acme-token"
+#     my_user: "me@acme.example"
+#     schedules:
+#       primary: "PXXXXXX"
+#     default_schedule: "primary"
+#   globex:
+#     pagerduty_token: "globex-token"
+#     my_user: "me@globex.example"
+#     schedule_id: "PAAAAAA"
+#     time_zone: "Europe/Berlin"
 `
 	fmt.Print(sample)
 }
@@ -187,6 +433,21 @@ func GetConfigPaths() []string {
 	return getConfigPaths()
 }
 
+// HistoryPath returns the file the REPL keeps its command history in:
+// $XDG_STATE_HOME/myshift/history, or ~/.local/state/myshift/history when
+// XDG_STATE_HOME is not set.
+func HistoryPath() (string, error) {
+	if xdgState := os.Getenv("XDG_STATE_HOME"); xdgState != "" {
+		return filepath.Join(xdgState, "myshift", "history"), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".local", "state", "myshift", "history"), nil
+}
+
 // ValidationResult represents the comprehensive result of configuration validation.
 // It provides detailed information about configuration file discovery, field validation,
 // errors, warnings, and guidance for fixing configuration issues.
@@ -198,6 +459,10 @@ type ValidationResult struct {
 	RequiredFields  map[string]bool // Required fields and whether they're present
 	OptionalFields  map[string]bool // Optional fields and whether they're present
 	ConfigLocations []string        // All locations that were searched for config files
+
+	Profile        string              // Name of the profile this result covers; empty for the whole file
+	DefaultProfile string              // The file's default_profile, if any
+	Profiles       []*ValidationResult // Results for each profile in the file, by name
 }
 
 // ValidateConfig performs comprehensive validation of the configuration and returns
@@ -221,14 +486,10 @@ func ValidateConfig() (*ValidationResult, error) {
 		ConfigLocations: configPathsFunc(),
 	}
 
-	// Try to find and load config from standard locations
-	var config *types.Config
-	var loadErr error
-
+	// Find the config file in the standard locations
 	for _, path := range result.ConfigLocations {
 		if _, err := os.Stat(path); err == nil {
 			result.ConfigPath = path
-			config, loadErr = loadFromFile(path)
 			break
 		}
 	}
@@ -238,32 +499,209 @@ func ValidateConfig() (*ValidationResult, error) {
 		return result, nil
 	}
 
-	if loadErr != nil {
-		result.Errors = append(result.Errors, fmt.Sprintf("Error loading config from %s: %v", result.ConfigPath, loadErr))
+	file, err := readFile(result.ConfigPath)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Error loading config from %s: %v", result.ConfigPath, err))
 		return result, nil
 	}
 
-	// Perform detailed validation
+	if mode, readable := readableByOthers(result.ConfigPath); readable {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Configuration file is readable by other users (mode %04o) - run 'chmod 600 %s'", mode, result.ConfigPath))
+	}
+
+	if len(file.Profiles) == 0 {
+		config, err := selectProfile(file, "")
+		if err == nil {
+			err = validate(config)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Error loading config from %s: %v", result.ConfigPath, err))
+			return result, nil
+		}
+		checkFields(config, result)
+		return result, nil
+	}
+
+	// Report on every profile, each merged over the top-level settings
+	result.Valid = true
+	result.DefaultProfile = file.DefaultProfile
+	if file.DefaultProfile != "" && file.Profiles[file.DefaultProfile] == nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("'default_profile' names unknown profile '%s'", file.DefaultProfile))
+		result.Valid = false
+	}
+	if file.DefaultProfile == "" && len(tokenSettings(file)) == 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("No 'default_profile' is set - you'll need --profile or %s for each command", ProfileEnv))
+	}
+
+	for _, name := range profileNames(file) {
+		profileResult := &ValidationResult{
+			ConfigPath:     result.ConfigPath,
+			RequiredFields: make(map[string]bool),
+			OptionalFields: make(map[string]bool),
+			Profile:        name,
+		}
+		config, err := selectProfile(file, name)
+		if err == nil {
+			err = validate(config)
+		}
+		if err != nil {
+			profileResult.Errors = append(profileResult.Errors, err.Error())
+		} else {
+			checkFields(config, profileResult)
+		}
+
+		result.Profiles = append(result.Profiles, profileResult)
+		result.Valid = result.Valid && profileResult.Valid
+	}
+
+	return result, nil
+}
+
+// checkFields records which required and optional fields config sets in
+// result, with warnings for useful optional fields that are missing.
+func checkFields(config *types.Config, result *ValidationResult) {
 	result.Valid = true
 
 	// Check required fields
-	result.RequiredFields["pagerduty_token"] = config.PagerDutyToken != ""
+	result.RequiredFields["pagerduty_token"] = len(tokenSettings(config)) > 0
 	if !result.RequiredFields["pagerduty_token"] {
 		result.Errors = append(result.Errors, "Required field 'pagerduty_token' is missing or empty")
 		result.Valid = false
 	}
 
+	// A token file must exist and, like the configuration, be private
+	if config.PagerDutyTokenFile != "" {
+		path := tokenFilePath(config.PagerDutyTokenFile, filepath.Dir(result.ConfigPath))
+		if _, err := os.Stat(path); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Token file %s cannot be read: %v", path, err))
+			result.Valid = false
+		} else if mode, readable := readableByOthers(path); readable {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Token file is readable by other users (mode %04o) - run 'chmod 600 %s'", mode, path))
+		}
+	}
+
 	// Check optional fields
 	result.OptionalFields["schedule_id"] = config.ScheduleID != ""
+	result.OptionalFields["schedules"] = len(config.Schedules) > 0
+	result.OptionalFields["default_schedule"] = config.DefaultSchedule != ""
 	result.OptionalFields["my_user"] = config.MyUser != ""
+	result.OptionalFields["templates"] = len(config.Templates) > 0
+	result.OptionalFields["time_zone"] = config.TimeZone != ""
 
 	// Add warnings for missing optional fields
-	if !result.OptionalFields["schedule_id"] {
+	if !result.OptionalFields["schedule_id"] && !result.OptionalFields["schedules"] {
 		result.Warnings = append(result.Warnings, "Optional field 'schedule_id' is not set - you'll need to specify schedule for each command")
 	}
+	if len(config.Schedules) > 1 && config.DefaultSchedule == "" && config.ScheduleID == "" {
+		result.Warnings = append(result.Warnings, "Several 'schedules' are configured but no 'default_schedule' is set - you'll need to specify --schedule for each command")
+	}
 	if !result.OptionalFields["my_user"] {
 		result.Warnings = append(result.Warnings, "Optional field 'my_user' is not set - you'll need to specify --user for next/upcoming commands")
 	}
+}
 
-	return result, nil
+// SetValue sets a top-level key in the YAML configuration file at path, adding
+// the key if it is not already present. The file is edited through a YAML node
+// tree so that existing comments and the order of other keys are preserved.
+//
+// Parameters:
+//   - path: The file system path to the YAML configuration file
+//   - key: The top-level configuration key (e.g. "schedule_id")
+//   - value: The string value to store
+//
+// Returns an error if the file cannot be read, parsed, or written.
+func SetValue(path, key, value string) error {
+	return SetProfileValue(path, "", key, value)
+}
+
+// SetProfileValue sets key like SetValue, but within the named profile of the
+// configuration file at path. An empty profile sets the top-level key. The
+// profile must already exist in the file.
+func SetProfileValue(path, profile, key, value string) error {
+	cleanPath := filepath.Clean(path)
+
+	data, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return fmt.Errorf("error reading config file %s: %w", cleanPath, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("error parsing config file %s: %w", cleanPath, err)
+	}
+
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s does not contain a YAML mapping", cleanPath)
+	}
+
+	if profile != "" {
+		if root = profileMapping(root, profile); root == nil {
+			return fmt.Errorf("config file %s has no profile '%s'", cleanPath, profile)
+		}
+	}
+
+	setMappingValue(root, key, value)
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return fmt.Errorf("error encoding config file %s: %w", cleanPath, err)
+	}
+
+	info, err := os.Stat(cleanPath)
+	if err != nil {
+		return fmt.Errorf("error reading config file %s: %w", cleanPath, err)
+	}
+
+	if err := os.WriteFile(cleanPath, out, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("error writing config file %s: %w", cleanPath, err)
+	}
+
+	return nil
+}
+
+// profileMapping returns the mapping node of the named profile under the
+// profiles key of root, or nil if there is no such profile.
+func profileMapping(root *yaml.Node, profile string) *yaml.Node {
+	profiles := mappingValue(root, "profiles")
+	if profiles == nil || profiles.Kind != yaml.MappingNode {
+		return nil
+	}
+	mapping := mappingValue(profiles, profile)
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	return mapping
+}
+
+// mappingValue returns the value node of key in a YAML mapping node, or nil
+// if the key is not present.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets key to a string value in a YAML mapping node, replacing
+// an existing entry or appending a new one.
+func setMappingValue(mapping *yaml.Node, key, value string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1].Kind = yaml.ScalarNode
+			mapping.Content[i+1].Tag = "!!str"
+			mapping.Content[i+1].Value = value
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
 }
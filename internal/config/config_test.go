@@ -15,6 +15,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -193,6 +194,31 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			testName: "IANA time zone",
+			config: &types.Config{
+				PagerDutyToken: "valid-token",
+				TimeZone:       "Asia/Kolkata",
+			},
+			wantErr: false,
+		},
+		{
+			testName: "schedule time zone",
+			config: &types.Config{
+				PagerDutyToken: "valid-token",
+				TimeZone:       "schedule",
+			},
+			wantErr: false,
+		},
+		{
+			testName: "unknown time zone",
+			config: &types.Config{
+				PagerDutyToken: "valid-token",
+				TimeZone:       "Mars/Olympus_Mons",
+			},
+			wantErr: true,
+			errMsg:  "is not a known time zone",
+		},
 	}
 
 	for _, tt := range tests {
@@ -258,7 +284,7 @@ schedule_id: "SCHED456"
 				t.Fatalf("Failed to create test file: %v", err)
 			}
 
-			config, err := loadFromFile(configPath)
+			config, err := loadFromFile(configPath, Options{})
 
 			if (err != nil) != tt.expectError {
 				t.Errorf("loadFromFile() error = %v, expectError %v", err, tt.expectError)
@@ -329,6 +355,27 @@ func TestGetConfigPaths(t *testing.T) {
 	}
 }
 
+func TestHistoryPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/custom/state")
+	path, err := HistoryPath()
+	if err != nil {
+		t.Fatalf("HistoryPath() failed: %v", err)
+	}
+	if path != filepath.Join("/custom/state", "myshift", "history") {
+		t.Errorf("HistoryPath() = %q, want path under XDG_STATE_HOME", path)
+	}
+
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("HOME", "/home/test")
+	path, err = HistoryPath()
+	if err != nil {
+		t.Fatalf("HistoryPath() failed: %v", err)
+	}
+	if path != filepath.Join("/home/test", ".local", "state", "myshift", "history") {
+		t.Errorf("HistoryPath() = %q, want path under ~/.local/state", path)
+	}
+}
+
 func TestValidateConfig_ValidConfig(t *testing.T) {
 	// Create a valid config file
 	tmpDir := t.TempDir()
@@ -340,7 +387,7 @@ schedule_id: "SCHED123"
 my_user: "test@example.com"
 `
 
-	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	err := os.WriteFile(configPath, []byte(configContent), 0600)
 	if err != nil {
 		t.Fatalf("Failed to create test config file: %v", err)
 	}
@@ -393,7 +440,7 @@ func TestValidateConfig_MinimalValidConfig(t *testing.T) {
 
 	configContent := `pagerduty_token: "test-token-123"`
 
-	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	err := os.WriteFile(configPath, []byte(configContent), 0600)
 	if err != nil {
 		t.Fatalf("Failed to create test config file: %v", err)
 	}
@@ -646,3 +693,577 @@ func TestValidateConfig_MultipleLocations(t *testing.T) {
 		t.Errorf("Expected 2 config locations, got %d", len(result.ConfigLocations))
 	}
 }
+
+func TestSetValue(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "myshift.yaml")
+
+	configContent := `# PagerDuty API token (required)
+pagerduty_token: "test-token-123"
+# schedule_id: "old"
+my_user: "test@example.com"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	if err := SetValue(configPath, "schedule_id", "PNEW123"); err != nil {
+		t.Fatalf("SetValue() failed: %v", err)
+	}
+	if err := SetValue(configPath, "my_user", "other@example.com"); err != nil {
+		t.Fatalf("SetValue() failed: %v", err)
+	}
+
+	config, err := loadFromFile(configPath, Options{})
+	if err != nil {
+		t.Fatalf("loadFromFile() failed: %v", err)
+	}
+	if config.ScheduleID != "PNEW123" {
+		t.Errorf("Expected ScheduleID 'PNEW123', got '%s'", config.ScheduleID)
+	}
+	if config.MyUser != "other@example.com" {
+		t.Errorf("Expected MyUser 'other@example.com', got '%s'", config.MyUser)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(data), "# PagerDuty API token (required)") {
+		t.Errorf("Expected comments to be preserved, got:\n%s", data)
+	}
+
+	info, _ := os.Stat(configPath)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected file mode 0600 to be preserved, got %v", info.Mode().Perm())
+	}
+}
+
+func TestLoad_ScheduleAliases(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "myshift.yaml")
+
+	configContent := `
+pagerduty_token: "test-token-123"
+default_schedule: primary
+schedules:
+  primary: PXXXX
+  db: PYYYY
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	config, err := loadFromFile(configPath, Options{})
+	if err != nil {
+		t.Fatalf("loadFromFile() failed: %v", err)
+	}
+	if config.DefaultSchedule != "primary" {
+		t.Errorf("Expected DefaultSchedule 'primary', got '%s'", config.DefaultSchedule)
+	}
+	if config.Schedules["primary"] != "PXXXX" || config.Schedules["db"] != "PYYYY" {
+		t.Errorf("Unexpected schedules: %v", config.Schedules)
+	}
+
+	if err := validate(&types.Config{PagerDutyToken: "x", Schedules: map[string]string{"empty": ""}}); err == nil {
+		t.Error("Expected error for schedule alias without ID")
+	}
+}
+
+func TestLoad_Templates(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "myshift.yaml")
+
+	configContent := `
+pagerduty_token: "test-token-123"
+templates:
+  oneline: "{{range .Shifts}}{{userName .}} {{end}}"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	config, err := loadFromFile(configPath, Options{})
+	if err != nil {
+		t.Fatalf("loadFromFile() failed: %v", err)
+	}
+	if config.Templates["oneline"] != "{{range .Shifts}}{{userName .}} {{end}}" {
+		t.Errorf("Unexpected templates: %v", config.Templates)
+	}
+
+	if err := validate(&types.Config{PagerDutyToken: "x", Templates: map[string]string{"empty": ""}}); err == nil {
+		t.Error("Expected error for empty template")
+	}
+}
+
+const profilesConfig = `time_zone: "UTC"
+my_user: "me@example.com"
+default_profile: "work"
+profiles:
+  work:
+    pagerduty_token: "work-token"
+    schedule_id: "PWORK"
+  oss:
+    pagerduty_token: "oss-token"
+    schedule_id: "POSS"
+    my_user: "me@oss.example.org"
+    time_zone: "Europe/Berlin"
+`
+
+func TestLoadProfile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "myshift.yaml")
+	if err := os.WriteFile(configPath, []byte(profilesConfig), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalConfigPathsFunc := configPathsFunc
+	configPathsFunc = func() []string {
+		return []string{configPath}
+	}
+	defer func() { configPathsFunc = originalConfigPathsFunc }()
+
+	tests := []struct {
+		name         string
+		profile      string
+		env          string
+		wantProfile  string
+		wantToken    string
+		wantSchedule string
+		wantUser     string
+		wantTimeZone string
+	}{
+		{"default profile", "", "", "work", "work-token", "PWORK", "me@example.com", "UTC"},
+		{"environment", "", "oss", "oss", "oss-token", "POSS", "me@oss.example.org", "Europe/Berlin"},
+		{"flag overrides environment", "work", "oss", "work", "work-token", "PWORK", "me@example.com", "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ProfileEnv, tt.env)
+
+			config, err := LoadProfile(tt.profile)
+			if err != nil {
+				t.Fatalf("LoadProfile() failed: %v", err)
+			}
+			if config.Profile != tt.wantProfile {
+				t.Errorf("Expected profile %q, got %q", tt.wantProfile, config.Profile)
+			}
+			if config.PagerDutyToken != tt.wantToken {
+				t.Errorf("Expected token %q, got %q", tt.wantToken, config.PagerDutyToken)
+			}
+			if config.ScheduleID != tt.wantSchedule {
+				t.Errorf("Expected ScheduleID %q, got %q", tt.wantSchedule, config.ScheduleID)
+			}
+			if config.MyUser != tt.wantUser {
+				t.Errorf("Expected MyUser %q, got %q", tt.wantUser, config.MyUser)
+			}
+			if config.TimeZone != tt.wantTimeZone {
+				t.Errorf("Expected TimeZone %q, got %q", tt.wantTimeZone, config.TimeZone)
+			}
+			if config.Profiles != nil {
+				t.Errorf("Expected profiles to be dropped from the selected config")
+			}
+		})
+	}
+}
+
+func TestLoadProfile_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv(ProfileEnv, "")
+
+	tests := []struct {
+		name    string
+		content string
+		profile string
+		wantErr string
+	}{
+		{
+			name:    "unknown profile",
+			content: profilesConfig,
+			profile: "home",
+			wantErr: "unknown profile 'home' (profiles: oss, work)",
+		},
+		{
+			name:    "no profiles configured",
+			content: "pagerduty_token: \"token\"\n",
+			profile: "work",
+			wantErr: "unknown profile 'work' (no profiles are configured)",
+		},
+		{
+			name:    "no profile selected",
+			content: "profiles:\n  work:\n    pagerduty_token: \"token\"\n",
+			wantErr: "no profile selected",
+		},
+		{
+			name:    "profile without token",
+			content: "profiles:\n  work:\n    schedule_id: \"PWORK\"\n",
+			profile: "work",
+			wantErr: "'pagerduty_token' is required in profile 'work'",
+		},
+		{
+			name:    "nested profiles",
+			content: "profiles:\n  work:\n    pagerduty_token: \"token\"\n    default_profile: \"oss\"\n",
+			profile: "work",
+			wantErr: "profile 'work' cannot contain profiles or default_profile",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(tmpDir, fmt.Sprintf("myshift-%d.yaml", i))
+			if err := os.WriteFile(configPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to create test config file: %v", err)
+			}
+
+			_, err := loadFromFile(configPath, Options{Profile: tt.profile})
+			if err == nil {
+				t.Fatalf("Expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadProfile_TopLevelToken(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "myshift.yaml")
+	t.Setenv(ProfileEnv, "")
+
+	content := `pagerduty_token: "shared-token"
+schedule_id: "PTOP"
+profiles:
+  oss:
+    schedule_id: "POSS"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	config, err := loadFromFile(configPath, Options{})
+	if err != nil {
+		t.Fatalf("loadFromFile() failed: %v", err)
+	}
+	if config.Profile != "" || config.ScheduleID != "PTOP" {
+		t.Errorf("Expected top-level settings without a profile, got profile %q schedule %q", config.Profile, config.ScheduleID)
+	}
+
+	config, err = loadFromFile(configPath, Options{Profile: "oss"})
+	if err != nil {
+		t.Fatalf("loadFromFile() failed: %v", err)
+	}
+	if config.PagerDutyToken != "shared-token" || config.ScheduleID != "POSS" {
+		t.Errorf("Expected shared token and profile schedule, got %q and %q", config.PagerDutyToken, config.ScheduleID)
+	}
+}
+
+func TestValidateConfig_Profiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "myshift.yaml")
+
+	content := profilesConfig + `  broken:
+    time_zone: "Not/AZone"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalConfigPathsFunc := configPathsFunc
+	configPathsFunc = func() []string {
+		return []string{configPath}
+	}
+	defer func() { configPathsFunc = originalConfigPathsFunc }()
+
+	result, err := ValidateConfig()
+	if err != nil {
+		t.Fatalf("ValidateConfig() failed: %v", err)
+	}
+
+	if result.Valid {
+		t.Error("Expected config with a broken profile to be invalid")
+	}
+	if result.DefaultProfile != "work" {
+		t.Errorf("Expected default profile 'work', got %q", result.DefaultProfile)
+	}
+	if len(result.Profiles) != 3 {
+		t.Fatalf("Expected 3 profile results, got %d", len(result.Profiles))
+	}
+
+	valid := make(map[string]bool)
+	for _, profile := range result.Profiles {
+		valid[profile.Profile] = profile.Valid
+	}
+	if !valid["work"] || !valid["oss"] || valid["broken"] {
+		t.Errorf("Expected work and oss valid and broken invalid, got %v", valid)
+	}
+
+	broken := result.Profiles[0]
+	if broken.Profile != "broken" || len(broken.Errors) != 1 || !strings.Contains(broken.Errors[0], "profile 'broken'") {
+		t.Errorf("Expected a missing token error for profile 'broken', got %+v", broken)
+	}
+}
+
+func TestSetProfileValue(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "myshift.yaml")
+	if err := os.WriteFile(configPath, []byte(profilesConfig), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	if err := SetProfileValue(configPath, "oss", "schedule_id", "PNEW"); err != nil {
+		t.Fatalf("SetProfileValue() failed: %v", err)
+	}
+
+	config, err := loadFromFile(configPath, Options{Profile: "oss"})
+	if err != nil {
+		t.Fatalf("loadFromFile() failed: %v", err)
+	}
+	if config.ScheduleID != "PNEW" {
+		t.Errorf("Expected ScheduleID 'PNEW', got '%s'", config.ScheduleID)
+	}
+
+	config, err = loadFromFile(configPath, Options{Profile: "work"})
+	if err != nil {
+		t.Fatalf("loadFromFile() failed: %v", err)
+	}
+	if config.ScheduleID != "PWORK" {
+		t.Errorf("Expected other profiles to be unchanged, got ScheduleID '%s'", config.ScheduleID)
+	}
+
+	if err := SetProfileValue(configPath, "home", "schedule_id", "PNEW"); err == nil {
+		t.Error("Expected error setting a value in an unknown profile")
+	}
+}
+
+func TestLoadWithOptions_Layers(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "myshift.yaml")
+	if err := os.WriteFile(configPath, []byte(profilesConfig), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalConfigPathsFunc := configPathsFunc
+	configPathsFunc = func() []string {
+		return []string{configPath}
+	}
+	defer func() { configPathsFunc = originalConfigPathsFunc }()
+
+	t.Setenv(ProfileEnv, "")
+	t.Setenv("MYSHIFT_SCHEDULE_ID", "PENV")
+	t.Setenv("MYSHIFT_MY_USER", "env@example.com")
+
+	config, err := LoadWithOptions(Options{
+		Flags: map[string]string{"my_user": "flag@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("LoadWithOptions() failed: %v", err)
+	}
+
+	want := map[string][2]string{
+		"pagerduty_token": {"work-token", "profile work"},
+		"schedule_id":     {"PENV", "$MYSHIFT_SCHEDULE_ID"},
+		"my_user":         {"flag@example.com", "--my-user"},
+		"time_zone":       {"UTC", "config file"},
+		"schedules":       {"", ""},
+	}
+	for _, setting := range Settings(config) {
+		expected, ok := want[setting.Key]
+		if !ok {
+			continue
+		}
+		if setting.Value != expected[0] || setting.Source != expected[1] {
+			t.Errorf("Expected %s = %q from %q, got %q from %q", setting.Key, expected[0], expected[1], setting.Value, setting.Source)
+		}
+	}
+}
+
+func TestLoadWithOptions_NoConfigFile(t *testing.T) {
+	originalConfigPathsFunc := configPathsFunc
+	configPathsFunc = func() []string {
+		return []string{"/non/existent/path/myshift.yaml"}
+	}
+	defer func() { configPathsFunc = originalConfigPathsFunc }()
+
+	t.Setenv(ProfileEnv, "")
+	t.Setenv("MYSHIFT_PAGERDUTY_TOKEN", "")
+
+	_, err := LoadWithOptions(Options{})
+	if err == nil || !strings.Contains(err.Error(), "MYSHIFT_PAGERDUTY_TOKEN is not set") {
+		t.Errorf("Expected error about the missing token, got %v", err)
+	}
+
+	t.Setenv("MYSHIFT_PAGERDUTY_TOKEN", "env-token")
+	config, err := LoadWithOptions(Options{Flags: map[string]string{"schedule_id": "PFLAG"}})
+	if err != nil {
+		t.Fatalf("LoadWithOptions() failed: %v", err)
+	}
+	if config.PagerDutyToken != "env-token" || config.ScheduleID != "PFLAG" {
+		t.Errorf("Expected token and schedule from environment and flags, got %q and %q", config.PagerDutyToken, config.ScheduleID)
+	}
+	if config.TimeZone != "local" || config.Sources["time_zone"] != "default" {
+		t.Errorf("Expected default time zone 'local', got %q from %q", config.TimeZone, config.Sources["time_zone"])
+	}
+
+	if _, err := LoadWithOptions(Options{Profile: "work"}); err == nil {
+		t.Error("Expected error selecting a profile without a config file")
+	}
+
+	_, err = LoadWithOptions(Options{Flags: map[string]string{"time_zone": "Not/AZone"}})
+	if err == nil || !strings.Contains(err.Error(), "not a known time zone") {
+		t.Errorf("Expected time zone validation error, got %v", err)
+	}
+}
+
+func TestLoad_TokenSources(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv(ProfileEnv, "")
+	t.Setenv("MYSHIFT_PAGERDUTY_TOKEN", "")
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "token"), []byte("file-token\n"), 0600); err != nil {
+		t.Fatalf("Failed to create token file: %v", err)
+	}
+
+	keyringDir := filepath.Join(tmpDir, "keyring")
+	if err := os.MkdirAll(filepath.Join(keyringDir, keyringService), 0700); err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(keyringDir, keyringService, "work"), []byte("keyring-token"), 0600); err != nil {
+		t.Fatalf("Failed to create keyring secret: %v", err)
+	}
+	originalKeyringFunc := keyringFunc
+	keyringFunc = func() Keyring { return fileKeyring{dir: keyringDir} }
+	defer func() { keyringFunc = originalKeyringFunc }()
+
+	tests := []struct {
+		name       string
+		content    string
+		wantToken  string
+		wantSource string
+		wantErr    string
+	}{
+		{
+			name:       "command",
+			content:    "pagerduty_token_command: \"printf 'command-token\\\\nextra\\\\n'\"\n",
+			wantToken:  "command-token",
+			wantSource: "pagerduty_token_command in config file",
+		},
+		{
+			name:       "relative file",
+			content:    "pagerduty_token_file: \"token\"\n",
+			wantToken:  "file-token",
+			wantSource: "pagerduty_token_file in config file",
+		},
+		{
+			name:       "keyring",
+			content:    "pagerduty_token_keyring: \"work\"\n",
+			wantToken:  "keyring-token",
+			wantSource: "pagerduty_token_keyring in config file",
+		},
+		{
+			name:       "profile replaces top-level token",
+			content:    "pagerduty_token: \"top-token\"\nprofiles:\n  work:\n    pagerduty_token_keyring: \"work\"\ndefault_profile: \"work\"\n",
+			wantToken:  "keyring-token",
+			wantSource: "pagerduty_token_keyring in profile work",
+		},
+		{
+			name:    "failing command",
+			content: "pagerduty_token_command: \"exit 3\"\n",
+			wantErr: "'pagerduty_token_command': error running",
+		},
+		{
+			name:    "missing keyring secret",
+			content: "pagerduty_token_keyring: \"home\"\n",
+			wantErr: "secret not found in keyring",
+		},
+		{
+			name:    "several token settings",
+			content: "pagerduty_token: \"token\"\npagerduty_token_file: \"token\"\n",
+			wantErr: "found pagerduty_token and pagerduty_token_file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(tmpDir, "myshift.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to create test config file: %v", err)
+			}
+
+			config, err := loadFromFile(configPath, Options{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadFromFile() failed: %v", err)
+			}
+			if config.PagerDutyToken != tt.wantToken {
+				t.Errorf("Expected token %q, got %q", tt.wantToken, config.PagerDutyToken)
+			}
+			if config.Sources["pagerduty_token"] != tt.wantSource {
+				t.Errorf("Expected source %q, got %q", tt.wantSource, config.Sources["pagerduty_token"])
+			}
+		})
+	}
+}
+
+func TestValidateConfig_Permissions(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "myshift.yaml")
+	tokenPath := filepath.Join(tmpDir, "token")
+
+	configContent := `pagerduty_token_file: "token"
+schedule_id: "SCHED123"
+my_user: "test@example.com"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalConfigPathsFunc := configPathsFunc
+	configPathsFunc = func() []string {
+		return []string{configPath}
+	}
+	defer func() { configPathsFunc = originalConfigPathsFunc }()
+
+	result, err := ValidateConfig()
+	if err != nil {
+		t.Fatalf("ValidateConfig() failed: %v", err)
+	}
+	if result.Valid || len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "Token file") {
+		t.Errorf("Expected a missing token file error, got %v", result.Errors)
+	}
+
+	if err := os.WriteFile(tokenPath, []byte("token\n"), 0640); err != nil {
+		t.Fatalf("Failed to create token file: %v", err)
+	}
+
+	result, err = ValidateConfig()
+	if err != nil {
+		t.Fatalf("ValidateConfig() failed: %v", err)
+	}
+	if !result.Valid || !result.RequiredFields["pagerduty_token"] {
+		t.Errorf("Expected config with a token file to be valid, got errors %v", result.Errors)
+	}
+	if len(result.Warnings) != 2 ||
+		!strings.Contains(result.Warnings[0], "Configuration file is readable by other users (mode 0644)") ||
+		!strings.Contains(result.Warnings[1], "Token file is readable by other users (mode 0640)") {
+		t.Errorf("Expected permission warnings for both files, got %v", result.Warnings)
+	}
+
+	if err := os.Chmod(configPath, 0600); err != nil {
+		t.Fatalf("Failed to change mode: %v", err)
+	}
+	if err := os.Chmod(tokenPath, 0600); err != nil {
+		t.Fatalf("Failed to change mode: %v", err)
+	}
+
+	result, err = ValidateConfig()
+	if err != nil {
+		t.Fatalf("ValidateConfig() failed: %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Expected no warnings for private files, got %v", result.Warnings)
+	}
+}
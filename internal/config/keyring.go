@@ -0,0 +1,95 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService is the service name under which myshift's secrets are
+// stored in a keyring.
+const keyringService = "myshift"
+
+// ErrSecretNotFound is returned (wrapped) when a keyring holds no secret for
+// the requested account.
+var ErrSecretNotFound = errors.New("secret not found in keyring")
+
+// Keyring looks up secrets stored under a service and account name.
+type Keyring interface {
+	Get(service, account string) (string, error)
+}
+
+// keyringFunc returns the keyring used for pagerduty_token_keyring. It is a
+// variable so tests can substitute a file-based keyring.
+var keyringFunc = systemKeyring
+
+// systemKeyring returns the keyring of the current platform: the login
+// keychain on macOS, and the Secret Service (GNOME Keyring, KWallet)
+// elsewhere.
+func systemKeyring() Keyring {
+	if runtime.GOOS == "darwin" {
+		return keychainKeyring{}
+	}
+	return secretServiceKeyring{}
+}
+
+// secretServiceKeyring reads secrets from the Secret Service with the
+// secret-tool command from libsecret. Secrets are stored with, for example,
+//
+//	secret-tool store --label "myshift work" service myshift account work
+type secretServiceKeyring struct{}
+
+// Get implements Keyring.
+func (secretServiceKeyring) Get(service, account string) (string, error) {
+	return runKeyringCommand(account, "secret-tool", "lookup", "service", service, "account", account)
+}
+
+// keychainKeyring reads secrets from the macOS keychain with the security
+// command. Secrets are stored with, for example,
+//
+//	security add-generic-password -s myshift -a work -w
+type keychainKeyring struct{}
+
+// Get implements Keyring.
+func (keychainKeyring) Get(service, account string) (string, error) {
+	return runKeyringCommand(account, "security", "find-generic-password", "-s", service, "-a", account, "-w")
+}
+
+// runKeyringCommand runs a keyring lookup command and returns the secret it
+// prints. A command that fails or prints nothing is taken to mean that there
+// is no such secret.
+func runKeyringCommand(account, name string, args ...string) (string, error) {
+	out, err := exec.Command(name, args...).Output()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return "", fmt.Errorf("error reading keyring with %s: %w", name, err)
+	}
+
+	secret := firstLine(out)
+	if err != nil || secret == "" {
+		return "", fmt.Errorf("account '%s': %w", account, ErrSecretNotFound)
+	}
+	return secret, nil
+}
+
+// firstLine returns the first line of out without surrounding whitespace.
+func firstLine(out []byte) string {
+	line, _, _ := strings.Cut(string(out), "\n")
+	return strings.TrimSpace(line)
+}
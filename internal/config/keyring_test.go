@@ -0,0 +1,41 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fileKeyring keeps each secret in a file named after its account, in a
+// directory named after its service under dir. It stands in for a system
+// keyring in tests.
+type fileKeyring struct {
+	dir string
+}
+
+// Get implements Keyring.
+func (k fileKeyring) Get(service, account string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(k.dir, service, account))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("account '%s': %w", account, ErrSecretNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading keyring: %w", err)
+	}
+	return firstLine(data), nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// Options controls how configuration is loaded: which profile to select, and
+// settings given as global flags, which take precedence over everything else.
+type Options struct {
+	Profile string            // Profile to select; see LoadProfile
+	Flags   map[string]string // Settings given as global flags, by key (e.g. "my_user")
+}
+
+// setting describes a configuration key and how to read it from a Config.
+// Settings with a field are plain strings that can also be given in the
+// environment and as global flags.
+type setting struct {
+	key   string
+	field func(*types.Config) *string
+	value func(*types.Config) string
+}
+
+// settings lists every configuration key in display order.
+var settings = []setting{
+	{key: "pagerduty_token", field: func(c *types.Config) *string { return &c.PagerDutyToken }},
+	{key: "pagerduty_token_command", value: func(c *types.Config) string { return c.PagerDutyTokenCommand }},
+	{key: "pagerduty_token_file", value: func(c *types.Config) string { return c.PagerDutyTokenFile }},
+	{key: "pagerduty_token_keyring", value: func(c *types.Config) string { return c.PagerDutyTokenKeyring }},
+	{key: "schedule_id", field: func(c *types.Config) *string { return &c.ScheduleID }},
+	{key: "schedules", value: func(c *types.Config) string { return joinKeys(c.Schedules) }},
+	{key: "default_schedule", field: func(c *types.Config) *string { return &c.DefaultSchedule }},
+	{key: "my_user", field: func(c *types.Config) *string { return &c.MyUser }},
+	{key: "templates", value: func(c *types.Config) string { return joinKeys(c.Templates) }},
+	{key: "time_zone", field: func(c *types.Config) *string { return &c.TimeZone }},
+}
+
+// defaults holds the values of settings that are not configured anywhere.
+var defaults = map[string]string{
+	"time_zone": "local",
+}
+
+// get returns the value of s in config, or "" if it is not set. Maps are
+// shown as their sorted keys.
+func (s setting) get(config *types.Config) string {
+	if s.field != nil {
+		return *s.field(config)
+	}
+	return s.value(config)
+}
+
+// joinKeys returns the sorted keys of m separated by commas.
+func joinKeys(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
+// OverrideKeys returns the keys of the settings that can be given as
+// environment variables and global flags.
+func OverrideKeys() []string {
+	var keys []string
+	for _, s := range settings {
+		if s.field != nil {
+			keys = append(keys, s.key)
+		}
+	}
+	return keys
+}
+
+// EnvVar returns the environment variable that sets key, such as
+// MYSHIFT_PAGERDUTY_TOKEN for pagerduty_token.
+func EnvVar(key string) string {
+	return "MYSHIFT_" + strings.ToUpper(key)
+}
+
+// FlagName returns the global flag that sets key, such as --pagerduty-token
+// for pagerduty_token.
+func FlagName(key string) string {
+	return "--" + strings.ReplaceAll(key, "_", "-")
+}
+
+// LoadWithOptions loads configuration in layers, each replacing the settings
+// of the layer before it: defaults, then the configuration file with the
+// selected profile, then MYSHIFT_* environment variables (see EnvVar), then
+// the global flags in opts. The configuration file is optional when a
+// PagerDuty token is given in the environment or as a flag.
+//
+// The returned Config records where each of its settings came from in
+// Sources.
+func LoadWithOptions(opts Options) (*types.Config, error) {
+	// Without a configuration file, only the other layers apply
+	path, _ := FindConfigFile()
+	return loadFromFile(path, opts)
+}
+
+// applyOverrides fills in defaults for unset settings, then replaces settings
+// with those given in the environment and then in flags, recording each
+// source in config.Sources.
+func applyOverrides(config *types.Config, flags map[string]string) {
+	for _, s := range settings {
+		if s.field == nil {
+			continue
+		}
+		field := s.field(config)
+		if *field == "" && defaults[s.key] != "" {
+			*field = defaults[s.key]
+			config.Sources[s.key] = "default"
+		}
+		if value := os.Getenv(EnvVar(s.key)); value != "" {
+			*field = value
+			config.Sources[s.key] = "$" + EnvVar(s.key)
+		}
+		if value := flags[s.key]; value != "" {
+			*field = value
+			config.Sources[s.key] = FlagName(s.key)
+		}
+	}
+}
+
+// fileSources records which settings of config were read from the file, and
+// which of those came from its selected profile rather than the top level.
+func fileSources(file, config *types.Config) map[string]string {
+	sources := make(map[string]string)
+	profile := file.Profiles[config.Profile]
+	for _, s := range settings {
+		switch {
+		case profile != nil && s.get(profile) != "":
+			sources[s.key] = fmt.Sprintf("profile %s", config.Profile)
+		case s.get(config) != "":
+			sources[s.key] = "config file"
+		}
+	}
+	return sources
+}
+
+// Setting is an effective configuration value and where it came from.
+type Setting struct {
+	Key    string // Configuration key, e.g. "my_user"
+	Value  string // Effective value; maps are shown as their sorted keys
+	Source string // Where the value came from; empty if it is not set
+}
+
+// Settings returns every setting of config in display order, with the
+// sources recorded by LoadWithOptions.
+func Settings(config *types.Config) []Setting {
+	result := make([]Setting, 0, len(settings))
+	for _, s := range settings {
+		value := s.get(config)
+		source := config.Sources[s.key]
+		if value == "" {
+			source = ""
+		}
+		result = append(result, Setting{Key: s.key, Value: value, Source: source})
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// tokenKeys lists the settings that give the PagerDuty token: the token
+// itself and its alternatives, at most one of which may be set.
+var tokenKeys = []string{"pagerduty_token", "pagerduty_token_command", "pagerduty_token_file", "pagerduty_token_keyring"}
+
+// tokenSettings returns the keys of the token settings set in config.
+func tokenSettings(config *types.Config) []string {
+	values := []string{config.PagerDutyToken, config.PagerDutyTokenCommand, config.PagerDutyTokenFile, config.PagerDutyTokenKeyring}
+
+	var keys []string
+	for i, value := range values {
+		if value != "" {
+			keys = append(keys, tokenKeys[i])
+		}
+	}
+	return keys
+}
+
+// checkTokenSettings returns an error if config gives the PagerDuty token in
+// more than one way.
+func checkTokenSettings(config *types.Config) error {
+	if keys := tokenSettings(config); len(keys) > 1 {
+		return fmt.Errorf("only one of %s may be set (found %s)", strings.Join(tokenKeys, ", "), strings.Join(keys, " and "))
+	}
+	return nil
+}
+
+// resolveToken sets the PagerDuty token of config from
+// pagerduty_token_command, pagerduty_token_file or pagerduty_token_keyring
+// unless a token is already set, recording where it came from. Relative
+// token file paths are taken relative to dir, the directory of the
+// configuration file.
+func resolveToken(config *types.Config, dir string) error {
+	var key, token string
+	var err error
+	switch {
+	case config.PagerDutyToken != "":
+		return nil
+	case config.PagerDutyTokenCommand != "":
+		key = "pagerduty_token_command"
+		token, err = runTokenCommand(config.PagerDutyTokenCommand)
+	case config.PagerDutyTokenFile != "":
+		key = "pagerduty_token_file"
+		token, err = readTokenFile(tokenFilePath(config.PagerDutyTokenFile, dir))
+	case config.PagerDutyTokenKeyring != "":
+		key = "pagerduty_token_keyring"
+		token, err = keyringFunc().Get(keyringService, config.PagerDutyTokenKeyring)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("'%s': %w", key, err)
+	}
+	if token == "" {
+		return fmt.Errorf("'%s' gave an empty token", key)
+	}
+
+	config.PagerDutyToken = token
+	config.Sources["pagerduty_token"] = fmt.Sprintf("%s in %s", key, config.Sources[key])
+	return nil
+}
+
+// runTokenCommand runs command with the shell and returns the first line of
+// its output, so password managers that print extra lines after the secret,
+// such as pass, can be used directly. The command shares the terminal, so it
+// can prompt for a passphrase.
+func runTokenCommand(command string) (string, error) {
+	cmd := exec.Command("sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error running %q: %w", command, err)
+	}
+	return firstLine(out), nil
+}
+
+// readTokenFile returns the first line of the file at path.
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading token file: %w", err)
+	}
+	return firstLine(data), nil
+}
+
+// tokenFilePath returns the path of a token file given in the configuration,
+// expanding a leading ~ to the home directory and resolving relative paths
+// against dir.
+func tokenFilePath(path, dir string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(homeDir, path[1:])
+		}
+	}
+	if !filepath.IsAbs(path) && dir != "" {
+		path = filepath.Join(dir, path)
+	}
+	return path
+}
+
+// readableByOthers reports whether the file at path can be read by users
+// other than its owner, and returns its permissions. It always reports false
+// on Windows, where permission bits do not apply.
+func readableByOthers(path string) (os.FileMode, bool) {
+	if runtime.GOOS == "windows" {
+		return 0, false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, false
+	}
+	mode := info.Mode().Perm()
+	return mode, mode&0o044 != 0
+}
@@ -26,9 +26,11 @@ package pagerduty
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"strings"
@@ -51,11 +53,16 @@ type Client struct {
 	apiToken   string
 	baseURL    string
 	httpClient *http.Client
+	retry      RetryPolicy
+	rnd        *rand.Rand
+	sleep      func(context.Context, time.Duration) error
+	now        func() time.Time
 }
 
 // NewClient creates a new PagerDuty API client with the provided API token.
-// The client is configured with a 30-second timeout and uses the standard
-// PagerDuty API base URL.
+// The client is configured with a 30-second timeout, uses the standard
+// PagerDuty API base URL, and retries transient failures according to
+// DefaultRetryPolicy.
 func NewClient(apiToken string) *Client {
 	return &Client{
 		apiToken: apiToken,
@@ -63,9 +70,20 @@ func NewClient(apiToken string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		retry: DefaultRetryPolicy(),
+		rnd:   rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404 -- jitter does not need a CSPRNG
+		sleep: sleepContext,
+		now:   time.Now,
 	}
 }
 
+// WithRetryPolicy replaces the client's retry policy and returns the client
+// to allow chaining from NewClient.
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+	c.retry = policy
+	return c
+}
+
 // APIResponse represents a generic API response wrapper containing pagination metadata.
 // This struct is embedded in all specific response types to provide consistent
 // pagination information across PagerDuty API endpoints.
@@ -90,40 +108,128 @@ type OnCallsResponse struct {
 	OnCalls []types.OnCall `json:"oncalls"`
 }
 
-// OverridesResponse represents the response from creating schedule overrides.
-// It contains the created override objects with their assigned IDs and metadata.
+// SchedulesResponse represents the response from the PagerDuty schedules API endpoint.
+// It contains an array of schedules matching the query along with pagination metadata.
+type SchedulesResponse struct {
+	APIResponse
+	Schedules []types.Schedule `json:"schedules"`
+}
+
+// OverridesResponse represents the response from listing or creating schedule overrides.
+// It contains the override objects with their assigned IDs and metadata.
 type OverridesResponse struct {
 	APIResponse
 	Overrides []types.Override `json:"overrides"`
 }
 
+// OverrideResult is one entry of the response to creating overrides. PagerDuty
+// reports a status per submitted override, so some may succeed while others fail.
+type OverrideResult struct {
+	Status   int            `json:"status"`
+	Override types.Override `json:"override"`
+	Errors   []string       `json:"errors,omitempty"`
+}
+
 // makeRequest makes an HTTP request to the PagerDuty API with proper authentication
 // and error handling. It sets required headers, handles request body marshaling,
 // and validates response status codes.
 //
+// Transient failures are retried according to the client's RetryPolicy. Rate
+// limit responses (429) are retried for every method, honoring Retry-After and
+// ratelimit-reset. Server errors and network failures are only retried for
+// idempotent methods, so a POST is never repeated after PagerDuty may have
+// accepted it.
+//
+// Cancelling ctx aborts both an in-flight request and any pending retry delay.
+//
 // Parameters:
+//   - ctx: Context controlling cancellation of the request and its retries
 //   - method: HTTP method (GET, POST, PUT, DELETE)
 //   - path: API endpoint path (e.g., "/users", "/oncalls")
 //   - params: URL query parameters
 //   - body: Request body object to be JSON-marshaled (can be nil)
 //
-// Returns the HTTP response or an error if the request fails or returns a non-2xx status.
-func (c *Client) makeRequest(method, path string, params url.Values, body interface{}) (*http.Response, error) {
+// Returns the HTTP response, or an error if the request fails. Non-2xx responses
+// are reported as *APIError so that callers can inspect the status and message.
+func (c *Client) makeRequest(ctx context.Context, method, path string, params url.Values, body interface{}) (*http.Response, error) {
 	reqURL := c.baseURL + path
 	if len(params) > 0 {
 		reqURL += "?" + params.Encode()
 	}
 
-	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("error marshaling request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, reqURL, reqBody)
+	started := c.now()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doRequest(ctx, method, reqURL, jsonBody)
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || !isIdempotent(method) || !c.canRetry(attempt) {
+				return nil, err
+			}
+			delay = c.retry.backoff(attempt, c.rnd)
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return resp, nil
+		default:
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close() // Explicitly ignore error - body already consumed
+			apiErr := newAPIError(resp, bodyBytes)
+			if !shouldRetryStatus(method, resp.StatusCode) || !c.canRetry(attempt) {
+				return nil, apiErr
+			}
+			var ok bool
+			if delay, ok = retryAfter(resp, c.now()); !ok {
+				delay = c.retry.backoff(attempt, c.rnd)
+			}
+			err = apiErr
+		}
+
+		if c.retry.MaxElapsed > 0 && c.now().Add(delay).Sub(started) > c.retry.MaxElapsed {
+			return nil, fmt.Errorf("giving up after %d attempt(s): %w", attempt, err)
+		}
+		if sleepErr := c.sleep(ctx, delay); sleepErr != nil {
+			return nil, fmt.Errorf("%w (last error: %w)", sleepErr, err)
+		}
+	}
+}
+
+// sleepContext waits for the given duration or until the context is done,
+// whichever comes first. It returns the context's error if it was cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// canRetry reports whether another attempt is allowed after the given attempt number.
+func (c *Client) canRetry(attempt int) bool {
+	return attempt < c.retry.MaxAttempts
+}
+
+// doRequest performs a single HTTP round trip with the standard PagerDuty headers.
+// The body is supplied as raw bytes so that it can be replayed on retries.
+func (c *Client) doRequest(ctx context.Context, method, reqURL string, jsonBody []byte) (*http.Response, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
@@ -138,12 +244,6 @@ func (c *Client) makeRequest(method, path string, params url.Values, body interf
 		return nil, fmt.Errorf("error making request: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		defer resp.Body.Close()
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: %d %s - %s", resp.StatusCode, resp.Status, string(bodyBytes))
-	}
-
 	return resp, nil
 }
 
@@ -153,16 +253,18 @@ func (c *Client) makeRequest(method, path string, params url.Values, body interf
 // for use in other API operations.
 //
 // Parameters:
+//   - ctx: Context for cancelling the request
 //   - email: The email address to search for
 //
-// Returns the matching User object or an error if the user is not found or the API call fails.
-func (c *Client) FindUserByEmail(email string) (*types.User, error) {
+// Returns the matching User object, or an error if the API call fails. When no user
+// matches, the error satisfies IsNotFound.
+func (c *Client) FindUserByEmail(ctx context.Context, email string) (*types.User, error) {
 	params := url.Values{
 		"query": []string{email},
 		"limit": []string{"1"},
 	}
 
-	resp, err := c.makeRequest("GET", "/users", params, nil)
+	resp, err := c.makeRequest(ctx, "GET", "/users", params, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -179,7 +281,7 @@ func (c *Client) FindUserByEmail(email string) (*types.User, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("user with email %s not found", email)
+	return nil, fmt.Errorf("user with email %s %w", email, ErrNotFound)
 }
 
 // GetUser retrieves detailed information about a PagerDuty user by their ID.
@@ -187,11 +289,12 @@ func (c *Client) FindUserByEmail(email string) (*types.User, error) {
 // It's typically used after finding a user ID through other means.
 //
 // Parameters:
+//   - ctx: Context for cancelling the request
 //   - userID: The PagerDuty user ID to retrieve
 //
 // Returns the User object with full details or an error if the user doesn't exist or the API call fails.
-func (c *Client) GetUser(userID string) (*types.User, error) {
-	resp, err := c.makeRequest("GET", "/users/"+userID, nil, nil)
+func (c *Client) GetUser(ctx context.Context, userID string) (*types.User, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/users/"+userID, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -222,15 +325,20 @@ func (c *Client) GetUser(userID string) (*types.User, error) {
 // The method handles pagination automatically, collecting all results before returning.
 //
 // Parameters:
+//   - ctx: Context for cancelling the request; checked between pages
 //   - params: URL query parameters for filtering the on-call shifts
 //
 // Returns a slice of OnCall objects matching the criteria, or an error if the API call fails.
-func (c *Client) GetOnCalls(params url.Values) ([]types.OnCall, error) {
+func (c *Client) GetOnCalls(ctx context.Context, params url.Values) ([]types.OnCall, error) {
 	var allOnCalls []types.OnCall
 	offset := 0
 	limit := 100
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		pageParams := make(url.Values)
 		for k, v := range params {
 			pageParams[k] = v
@@ -238,7 +346,7 @@ func (c *Client) GetOnCalls(params url.Values) ([]types.OnCall, error) {
 		pageParams.Set("offset", fmt.Sprintf("%d", offset))
 		pageParams.Set("limit", fmt.Sprintf("%d", limit))
 
-		resp, err := c.makeRequest("GET", "/oncalls", pageParams, nil)
+		resp, err := c.makeRequest(ctx, "GET", "/oncalls", pageParams, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -271,22 +379,193 @@ func (c *Client) GetOnCalls(params url.Values) ([]types.OnCall, error) {
 //   - User reference (ID and type)
 //   - Optional timezone information
 //
-// This operation is atomic - either all overrides are created successfully,
-// or none are created if any validation fails.
+// PagerDuty validates each override separately, so a request can partially
+// succeed. The overrides that were created are always returned, along with an
+// error describing any that were rejected, so callers can roll them back.
 //
 // Parameters:
+//   - ctx: Context for cancelling the request
 //   - scheduleID: The PagerDuty schedule ID to create overrides for
 //   - overrides: Slice of Override objects to create
 //
-// Returns nil on success, or an error if validation fails or the API call fails.
-func (c *Client) CreateOverrides(scheduleID string, overrides []types.Override) error {
+// Returns the created overrides with their assigned IDs, or an error if any
+// override was rejected or the API call fails.
+func (c *Client) CreateOverrides(ctx context.Context, scheduleID string, overrides []types.Override) ([]types.Override, error) {
 	requestBody := struct {
 		Overrides []types.Override `json:"overrides"`
 	}{
 		Overrides: overrides,
 	}
 
-	resp, err := c.makeRequest("POST", "/schedules/"+scheduleID+"/overrides", nil, requestBody)
+	resp, err := c.makeRequest(ctx, "POST", "/schedules/"+scheduleID+"/overrides", nil, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	var results []OverrideResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	var created []types.Override
+	var rejected *APIError
+	for _, result := range results {
+		if result.Status >= 200 && result.Status < 300 {
+			created = append(created, result.Override)
+			continue
+		}
+		if rejected == nil {
+			rejected = &APIError{StatusCode: result.Status, Message: "Override rejected", Body: string(body)}
+		}
+		rejected.Errors = append(rejected.Errors, result.Errors...)
+	}
+	if rejected != nil {
+		return created, rejected
+	}
+
+	return created, nil
+}
+
+// ListSchedules retrieves the schedules visible to the API token, optionally
+// filtered by name. Like GetOnCalls, it handles pagination automatically and
+// returns all matching schedules.
+//
+// Parameters:
+//   - ctx: Context for cancelling the request; checked between pages
+//   - query: Filter schedules whose name contains this string (empty for all)
+//
+// Returns a slice of Schedule objects, or an error if the API call fails.
+func (c *Client) ListSchedules(ctx context.Context, query string) ([]types.Schedule, error) {
+	var allSchedules []types.Schedule
+	offset := 0
+	limit := 100
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pageParams := NewParamsBuilder().Offset(offset).Limit(limit)
+		if query != "" {
+			pageParams.Query(query)
+		}
+
+		resp, err := c.makeRequest(ctx, "GET", "/schedules", pageParams.Build(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var schedulesResp SchedulesResponse
+		if err := json.NewDecoder(resp.Body).Decode(&schedulesResp); err != nil {
+			_ = resp.Body.Close() // Explicitly ignore error during error handling
+			return nil, fmt.Errorf("error decoding response: %w", err)
+		}
+		_ = resp.Body.Close() // Explicitly ignore error - response already processed
+
+		allSchedules = append(allSchedules, schedulesResp.Schedules...)
+
+		if !schedulesResp.More || len(schedulesResp.Schedules) < limit {
+			break
+		}
+
+		offset += limit
+	}
+
+	return allSchedules, nil
+}
+
+// GetSchedule retrieves a single PagerDuty schedule by its ID, including its
+// name, time zone and owning teams.
+//
+// Parameters:
+//   - ctx: Context for cancelling the request
+//   - scheduleID: The PagerDuty schedule ID to retrieve
+//
+// Returns the Schedule object or an error if the schedule doesn't exist or the API call fails.
+func (c *Client) GetSchedule(ctx context.Context, scheduleID string) (*types.Schedule, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/schedules/"+scheduleID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Schedule types.Schedule `json:"schedule"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return &result.Schedule, nil
+}
+
+// ListOverrides retrieves the overrides on a schedule that overlap a time range.
+// Like GetOnCalls, it handles pagination automatically and returns all matches.
+//
+// Parameters:
+//   - ctx: Context for cancelling the request; checked between pages
+//   - scheduleID: The PagerDuty schedule ID whose overrides to list
+//   - since: Start of the time range
+//   - until: End of the time range
+//
+// Returns a slice of Override objects (including their IDs), or an error if the API call fails.
+func (c *Client) ListOverrides(ctx context.Context, scheduleID string, since, until time.Time) ([]types.Override, error) {
+	var allOverrides []types.Override
+	offset := 0
+	limit := 100
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pageParams := NewParamsBuilder().TimeRange(since, until).Offset(offset).Limit(limit).Build()
+
+		resp, err := c.makeRequest(ctx, "GET", "/schedules/"+scheduleID+"/overrides", pageParams, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var overridesResp OverridesResponse
+		if err := json.NewDecoder(resp.Body).Decode(&overridesResp); err != nil {
+			_ = resp.Body.Close() // Explicitly ignore error during error handling
+			return nil, fmt.Errorf("error decoding response: %w", err)
+		}
+		_ = resp.Body.Close() // Explicitly ignore error - response already processed
+
+		allOverrides = append(allOverrides, overridesResp.Overrides...)
+
+		if !overridesResp.More || len(overridesResp.Overrides) < limit {
+			break
+		}
+
+		offset += limit
+	}
+
+	return allOverrides, nil
+}
+
+// DeleteOverride removes a single override from a schedule. Overrides that
+// have already started are truncated by PagerDuty rather than removed.
+//
+// Parameters:
+//   - ctx: Context for cancelling the request
+//   - scheduleID: The PagerDuty schedule ID the override belongs to
+//   - overrideID: The ID of the override to delete
+//
+// Returns nil on success, or an error if the override doesn't exist or the API call fails.
+func (c *Client) DeleteOverride(ctx context.Context, scheduleID, overrideID string) error {
+	resp, err := c.makeRequest(ctx, "DELETE", "/schedules/"+scheduleID+"/overrides/"+overrideID, nil, nil)
 	if err != nil {
 		return err
 	}
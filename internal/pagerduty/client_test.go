@@ -0,0 +1,452 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jdcasey/myshift-go/internal/types"
+)
+
+// newTestClient creates a client pointed at the given test server that records
+// retry delays instead of sleeping.
+func newTestClient(serverURL string) (*Client, *[]time.Duration) {
+	var delays []time.Duration
+	client := NewClient("test-token")
+	client.baseURL = serverURL
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return client, &delays
+}
+
+func TestMakeRequest_RetriesRateLimitWithRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"oncalls": [], "more": false}`))
+	}))
+	defer server.Close()
+
+	client, delays := newTestClient(server.URL)
+
+	if _, err := client.GetOnCalls(context.Background(), url.Values{}); err != nil {
+		t.Fatalf("GetOnCalls() failed: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+	if len(*delays) != 1 || (*delays)[0] != 3*time.Second {
+		t.Errorf("Expected a single 3s delay, got %v", *delays)
+	}
+}
+
+func TestMakeRequest_RetriesRateLimitReset(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("ratelimit-reset", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"user": {"id": "USER001"}}`))
+	}))
+	defer server.Close()
+
+	client, delays := newTestClient(server.URL)
+
+	if _, err := client.GetUser(context.Background(), "USER001"); err != nil {
+		t.Fatalf("GetUser() failed: %v", err)
+	}
+	if len(*delays) != 1 || (*delays)[0] != 7*time.Second {
+		t.Errorf("Expected a single 7s delay, got %v", *delays)
+	}
+}
+
+func TestMakeRequest_RetriesServerErrorsWithBackoff(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"user": {"id": "USER001"}}`))
+	}))
+	defer server.Close()
+
+	client, delays := newTestClient(server.URL)
+
+	if _, err := client.GetUser(context.Background(), "USER001"); err != nil {
+		t.Fatalf("GetUser() failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+	policy := DefaultRetryPolicy()
+	for i, delay := range *delays {
+		maxDelay := policy.InitialDelay << i
+		if delay <= 0 || delay > maxDelay {
+			t.Errorf("Delay %d = %v, expected within (0, %v]", i, delay, maxDelay)
+		}
+	}
+}
+
+func TestMakeRequest_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL)
+	client.WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond})
+
+	if _, err := client.GetUser(context.Background(), "USER001"); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestMakeRequest_RespectsMaxElapsed(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, delays := newTestClient(server.URL)
+
+	if _, err := client.GetUser(context.Background(), "USER001"); err == nil {
+		t.Fatal("Expected error when Retry-After exceeds MaxElapsed")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+	if len(*delays) != 0 {
+		t.Errorf("Expected no sleeps, got %v", *delays)
+	}
+}
+
+func TestCreateOverrides_DoesNotRetryServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL)
+
+	overrides := []types.Override{{Start: time.Now(), End: time.Now().Add(time.Hour)}}
+	if _, err := client.CreateOverrides(context.Background(), "SCHED123", overrides); err == nil {
+		t.Fatal("Expected error from CreateOverrides")
+	}
+	if calls != 1 {
+		t.Errorf("Expected POST to be attempted once, got %d", calls)
+	}
+}
+
+func TestCreateOverrides_RetriesRateLimit(t *testing.T) {
+	var calls int32
+	var lastBody int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.StoreInt64(&lastBody, r.ContentLength)
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL)
+
+	overrides := []types.Override{{Start: time.Now(), End: time.Now().Add(time.Hour)}}
+	if _, err := client.CreateOverrides(context.Background(), "SCHED123", overrides); err != nil {
+		t.Fatalf("CreateOverrides() failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+	if lastBody <= 0 {
+		t.Errorf("Expected request body to be replayed on retry, got length %d", lastBody)
+	}
+}
+
+func TestCreateOverrides_Results(t *testing.T) {
+	tests := []struct {
+		testName    string
+		response    string
+		wantIDs     []string
+		wantErr     bool
+		wantErrText string
+	}{
+		{
+			testName: "all created",
+			response: `[{"status":201,"override":{"id":"PO1"}},{"status":201,"override":{"id":"PO2"}}]`,
+			wantIDs:  []string{"PO1", "PO2"},
+		},
+		{
+			testName:    "partial failure",
+			response:    `[{"status":201,"override":{"id":"PO1"}},{"status":400,"errors":["Override must end after its start"]}]`,
+			wantIDs:     []string{"PO1"},
+			wantErr:     true,
+			wantErrText: "Override must end after its start",
+		},
+		{
+			testName: "empty body",
+			response: ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client, _ := newTestClient(server.URL)
+
+			overrides := []types.Override{{Start: time.Now(), End: time.Now().Add(time.Hour)}}
+			created, err := client.CreateOverrides(context.Background(), "SCHED123", overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !IsValidation(err) || !strings.Contains(err.Error(), tt.wantErrText) {
+					t.Errorf("Expected validation error containing %q, got %v", tt.wantErrText, err)
+				}
+			}
+
+			if len(created) != len(tt.wantIDs) {
+				t.Fatalf("Expected %d created overrides, got %d", len(tt.wantIDs), len(created))
+			}
+			for i, id := range tt.wantIDs {
+				if created[i].ID != id {
+					t.Errorf("Expected override %d to have ID %s, got %s", i, id, created[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		testName  string
+		headers   map[string]string
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{"seconds", map[string]string{"Retry-After": "5"}, 5 * time.Second, true},
+		{"http date", map[string]string{"Retry-After": now.Add(10 * time.Second).Format(http.TimeFormat)}, 10 * time.Second, true},
+		{"ratelimit reset", map[string]string{"ratelimit-reset": "2"}, 2 * time.Second, true},
+		{"no headers", map[string]string{}, 0, false},
+		{"garbage", map[string]string{"Retry-After": "soon"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resp := &http.Response{Header: make(http.Header)}
+			for k, v := range tt.headers {
+				resp.Header.Set(k, v)
+			}
+
+			delay, ok := retryAfter(resp, now)
+			if ok != tt.wantOK || delay != tt.wantDelay {
+				t.Errorf("retryAfter() = (%v, %v), want (%v, %v)", delay, ok, tt.wantDelay, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGetOnCalls_CancelledContext(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"oncalls": [], "more": false}`))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetOnCalls(ctx, url.Values{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected no calls after cancellation, got %d", calls)
+	}
+}
+
+func TestMakeRequest_CancelDuringRetryDelay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err := client.GetUser(ctx, "USER001")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to interrupt the retry delay, took %v", elapsed)
+	}
+}
+
+func TestListSchedules_Paginates(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schedules" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		queries = append(queries, r.URL.Query())
+
+		schedules := make([]types.Schedule, 100)
+		more := r.URL.Query().Get("offset") == "0"
+		if !more {
+			schedules = schedules[:1]
+		}
+		for i := range schedules {
+			schedules[i].ID = fmt.Sprintf("P%s-%d", r.URL.Query().Get("offset"), i)
+		}
+		_ = json.NewEncoder(w).Encode(SchedulesResponse{
+			APIResponse: APIResponse{More: more},
+			Schedules:   schedules,
+		})
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL)
+
+	schedules, err := client.ListSchedules(context.Background(), "primary")
+	if err != nil {
+		t.Fatalf("ListSchedules() failed: %v", err)
+	}
+
+	if len(schedules) != 101 {
+		t.Errorf("Expected 101 schedules, got %d", len(schedules))
+	}
+	if len(queries) != 2 || queries[1].Get("offset") != "100" {
+		t.Errorf("Expected a second page at offset 100, got %v", queries)
+	}
+	if queries[0].Get("query") != "primary" {
+		t.Errorf("Expected query parameter 'primary', got %q", queries[0].Get("query"))
+	}
+}
+
+func TestGetSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schedules/PABC123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"schedule": {"id": "PABC123", "name": "Primary", "time_zone": "Europe/Berlin",
+			"teams": [{"id": "PTEAM1", "summary": "Platform"}]}}`))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL)
+
+	schedule, err := client.GetSchedule(context.Background(), "PABC123")
+	if err != nil {
+		t.Fatalf("GetSchedule() failed: %v", err)
+	}
+	if schedule.TimeZone != "Europe/Berlin" || len(schedule.Teams) != 1 || schedule.Teams[0].Summary != "Platform" {
+		t.Errorf("Unexpected schedule: %+v", schedule)
+	}
+
+	if _, err := client.GetSchedule(context.Background(), "PMISSING"); !IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestListOverrides(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schedules/SCHED123/overrides" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("since") == "" || r.URL.Query().Get("until") == "" {
+			t.Errorf("Expected since and until parameters, got %v", r.URL.Query())
+		}
+		_, _ = w.Write([]byte(`{"overrides": [{"id": "PO1", "start": "2025-01-15T09:00:00Z", "end": "2025-01-15T17:00:00Z",
+			"user": {"id": "USER001", "type": "user_reference", "summary": "John Doe"}}], "more": false}`))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL)
+
+	since := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	overrides, err := client.ListOverrides(context.Background(), "SCHED123", since, since.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("ListOverrides() failed: %v", err)
+	}
+	if len(overrides) != 1 || overrides[0].ID != "PO1" || overrides[0].User.Summary != "John Doe" {
+		t.Errorf("Unexpected overrides: %+v", overrides)
+	}
+}
+
+func TestDeleteOverride(t *testing.T) {
+	var method, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL)
+
+	if err := client.DeleteOverride(context.Background(), "SCHED123", "PO1"); err != nil {
+		t.Fatalf("DeleteOverride() failed: %v", err)
+	}
+	if method != http.MethodDelete || path != "/schedules/SCHED123/overrides/PO1" {
+		t.Errorf("Unexpected request %s %s", method, path)
+	}
+}
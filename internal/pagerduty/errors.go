@@ -0,0 +1,130 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagerduty
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound is returned (wrapped) when a lookup succeeds at the API level
+// but finds no matching object, such as FindUserByEmail with an unknown email.
+var ErrNotFound = errors.New("not found")
+
+// APIError represents a non-2xx response from the PagerDuty API.
+//
+// PagerDuty reports errors using an envelope of the form
+//
+//	{"error": {"code": 2001, "message": "Invalid Input Provided", "errors": ["..."]}}
+//
+// which is parsed into Code, Message and Errors when present. Body always holds
+// the raw response body so nothing is lost when the envelope is missing.
+type APIError struct {
+	StatusCode int      // HTTP status code of the response
+	Status     string   // HTTP status line text (e.g. "404 Not Found")
+	Code       int      // PagerDuty-specific error code, 0 if not provided
+	Message    string   // PagerDuty error message, empty if not provided
+	Errors     []string // Detailed error messages, typically validation failures
+	Body       string   // Raw response body
+}
+
+// errorEnvelope mirrors the JSON structure PagerDuty uses for error responses.
+type errorEnvelope struct {
+	Error struct {
+		Code    int      `json:"code"`
+		Message string   `json:"message"`
+		Errors  []string `json:"errors"`
+	} `json:"error"`
+}
+
+// newAPIError builds an APIError from a response and its already-read body.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(body),
+	}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal(body, &envelope); err == nil {
+		apiErr.Code = envelope.Error.Code
+		apiErr.Message = envelope.Error.Message
+		apiErr.Errors = envelope.Error.Errors
+	}
+
+	return apiErr
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("API error: %s - %s", e.statusText(), strings.TrimSpace(e.Body))
+	}
+
+	msg := fmt.Sprintf("API error: %s - %s", e.statusText(), e.Message)
+	if e.Code != 0 {
+		msg += fmt.Sprintf(" (code %d)", e.Code)
+	}
+	if len(e.Errors) > 0 {
+		msg += ": " + strings.Join(e.Errors, "; ")
+	}
+	return msg
+}
+
+// statusText returns the HTTP status for display, falling back to the code
+// alone when the status line is unavailable.
+func (e *APIError) statusText() string {
+	if e.Status != "" {
+		return e.Status
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// hasStatus reports whether err is, or wraps, an APIError with the given status code.
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// IsNotFound reports whether err indicates that the requested object does not exist,
+// either because the API returned 404 or because a lookup found no match.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err indicates an invalid or missing API token (401).
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err indicates that the token lacks permission for
+// the operation (403), such as a read-only token attempting to create overrides.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsRateLimited reports whether err indicates that the API rate limit was exceeded (429).
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsValidation reports whether err indicates that PagerDuty rejected the request
+// input, for example an override with an invalid time range (400 or 422).
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest) || hasStatus(err, http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMakeRequest_ReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": {"code": 2001, "message": "Invalid Input Provided", "errors": ["Override must end after its start"]}}`))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(server.URL)
+
+	_, err := client.CreateOverrides(context.Background(), "SCHED123", nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != 2001 {
+		t.Errorf("Unexpected status/code: %d/%d", apiErr.StatusCode, apiErr.Code)
+	}
+	if apiErr.Message != "Invalid Input Provided" {
+		t.Errorf("Unexpected message: %q", apiErr.Message)
+	}
+	if len(apiErr.Errors) != 1 {
+		t.Errorf("Expected 1 detailed error, got %v", apiErr.Errors)
+	}
+	if !IsValidation(err) {
+		t.Error("Expected IsValidation to be true")
+	}
+	if !strings.Contains(err.Error(), "Override must end after its start") {
+		t.Errorf("Expected detailed error in message, got %q", err.Error())
+	}
+}
+
+func TestAPIError_NonJSONBody(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"}
+	apiErr := newAPIError(resp, []byte("upstream unavailable"))
+
+	if apiErr.Message != "" {
+		t.Errorf("Expected empty message, got %q", apiErr.Message)
+	}
+	if got := apiErr.Error(); got != "API error: 502 Bad Gateway - upstream unavailable" {
+		t.Errorf("Unexpected error string: %q", got)
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	tests := []struct {
+		testName string
+		err      error
+		check    func(error) bool
+		want     bool
+	}{
+		{"not found status", &APIError{StatusCode: 404}, IsNotFound, true},
+		{"not found sentinel", fmt.Errorf("user with email x %w", ErrNotFound), IsNotFound, true},
+		{"unauthorized", &APIError{StatusCode: 401}, IsUnauthorized, true},
+		{"forbidden", &APIError{StatusCode: 403}, IsForbidden, true},
+		{"rate limited wrapped", fmt.Errorf("giving up: %w", &APIError{StatusCode: 429}), IsRateLimited, true},
+		{"validation 422", &APIError{StatusCode: 422}, IsValidation, true},
+		{"plain error", errors.New("boom"), IsNotFound, false},
+		{"nil error", nil, IsUnauthorized, false},
+		{"other status", &APIError{StatusCode: 500}, IsForbidden, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := tt.check(tt.err); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
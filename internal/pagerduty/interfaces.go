@@ -15,7 +15,9 @@
 package pagerduty
 
 import (
+	"context"
 	"net/url"
+	"time"
 
 	"github.com/jdcasey/myshift-go/internal/types"
 )
@@ -26,25 +28,42 @@ import (
 //
 // All methods handle authentication automatically using the API token
 // provided during client creation. Errors are wrapped with contextual
-// information to aid in debugging API issues.
+// information to aid in debugging API issues. Every method takes a
+// context.Context so that in-flight requests can be cancelled.
 type PagerDutyClient interface {
 	// FindUserByEmail searches for a user by their email address.
 	// Returns the first user matching the provided email address.
-	FindUserByEmail(email string) (*types.User, error)
+	FindUserByEmail(ctx context.Context, email string) (*types.User, error)
 
 	// GetUser retrieves a user by their PagerDuty user ID.
 	// Returns detailed user information including name, email, and type.
-	GetUser(userID string) (*types.User, error)
+	GetUser(ctx context.Context, userID string) (*types.User, error)
 
 	// GetOnCalls retrieves on-call shifts based on the provided parameters.
 	// Supports filtering by time range, users, schedules, and other criteria.
 	// Automatically handles pagination to return all matching results.
-	GetOnCalls(params url.Values) ([]types.OnCall, error)
+	GetOnCalls(ctx context.Context, params url.Values) ([]types.OnCall, error)
+
+	// ListSchedules retrieves all schedules, optionally filtered by a name query.
+	// Automatically handles pagination to return all matching results.
+	ListSchedules(ctx context.Context, query string) ([]types.Schedule, error)
+
+	// GetSchedule retrieves a schedule by its PagerDuty schedule ID.
+	// Returns the schedule's name, time zone and teams.
+	GetSchedule(ctx context.Context, scheduleID string) (*types.Schedule, error)
 
 	// CreateOverrides creates one or more schedule overrides for the specified schedule.
 	// Each override temporarily assigns a different user to handle on-call duties
-	// during the specified time period.
-	CreateOverrides(scheduleID string, overrides []types.Override) error
+	// during the specified time period. Returns the created overrides with their IDs,
+	// including on partial failure, so callers can roll them back.
+	CreateOverrides(ctx context.Context, scheduleID string, overrides []types.Override) ([]types.Override, error)
+
+	// ListOverrides retrieves the overrides on a schedule that overlap the given time range.
+	// Automatically handles pagination to return all matching results.
+	ListOverrides(ctx context.Context, scheduleID string, since, until time.Time) ([]types.Override, error)
+
+	// DeleteOverride removes an override from the specified schedule.
+	DeleteOverride(ctx context.Context, scheduleID, overrideID string) error
 }
 
 // Ensure Client implements PagerDutyClient interface
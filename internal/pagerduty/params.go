@@ -55,6 +55,22 @@ func (p *ParamsBuilder) Schedules(scheduleIDs ...string) *ParamsBuilder {
 	return p
 }
 
+// EscalationPolicies adds escalation policy ID parameters
+func (p *ParamsBuilder) EscalationPolicies(policyIDs ...string) *ParamsBuilder {
+	for _, id := range policyIDs {
+		p.params.Add("escalation_policy_ids[]", id)
+	}
+	return p
+}
+
+// Include adds related objects to embed in the response, such as "users"
+func (p *ParamsBuilder) Include(includes ...string) *ParamsBuilder {
+	for _, include := range includes {
+		p.params.Add("include[]", include)
+	}
+	return p
+}
+
 // Overflow sets the overflow parameter
 func (p *ParamsBuilder) Overflow(overflow bool) *ParamsBuilder {
 	p.params.Set("overflow", strconv.FormatBool(overflow))
@@ -0,0 +1,126 @@
+// Copyright 2025 John Casey
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagerduty
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how the client retries failed API requests.
+//
+// Requests are retried with exponential backoff and jitter. When PagerDuty
+// supplies a Retry-After or ratelimit-reset header, that delay is used instead
+// of the computed backoff. No retry is attempted once the total time spent
+// retrying would exceed MaxElapsed.
+type RetryPolicy struct {
+	MaxAttempts  int           // Maximum number of attempts, including the first (1 disables retries)
+	InitialDelay time.Duration // Backoff delay before the first retry
+	MaxDelay     time.Duration // Upper bound for a single backoff delay
+	MaxElapsed   time.Duration // Upper bound for the total time spent retrying
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  5,
+		InitialDelay: 500 * time.Millisecond,
+		MaxDelay:     30 * time.Second,
+		MaxElapsed:   2 * time.Minute,
+	}
+}
+
+// NoRetryPolicy returns a retry policy that never retries.
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// backoff returns the delay before retry number attempt (starting at 1).
+// The delay doubles on every attempt up to MaxDelay, and a random jitter of
+// up to half the delay is subtracted so that concurrent clients spread out.
+func (p RetryPolicy) backoff(attempt int, rnd *rand.Rand) time.Duration {
+	delay := p.InitialDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay - time.Duration(rnd.Int63n(int64(delay)/2+1))
+}
+
+// isIdempotent reports whether a request with the given method can be safely
+// repeated after the server may already have processed it.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetryStatus reports whether a response status is worth retrying for
+// the given method. A 429 means PagerDuty rejected the request before doing
+// any work, so it is safe to retry for every method. Server errors may be
+// returned after a write has been applied, so they are only retried for
+// idempotent methods; retrying a POST there could create duplicate overrides.
+func shouldRetryStatus(method string, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	if !isIdempotent(method) {
+		return false
+	}
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter extracts the server-requested delay from a response. It honors
+// the standard Retry-After header (in seconds or as an HTTP date) and
+// PagerDuty's ratelimit-reset header (seconds until the window resets).
+// The second return value is false when neither header is usable.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if value := strings.TrimSpace(resp.Header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if when, err := http.ParseTime(value); err == nil {
+			if delay := when.Sub(now); delay > 0 {
+				return delay, true
+			}
+			return 0, true
+		}
+	}
+
+	if value := strings.TrimSpace(resp.Header.Get("ratelimit-reset")); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds * float64(time.Second)), true
+		}
+	}
+
+	return 0, false
+}
@@ -27,8 +27,15 @@ type User struct {
 
 // UserReference represents a reference to a PagerDuty user.
 type UserReference struct {
-	ID   string `json:"id"`
-	Type string `json:"type"`
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Summary string `json:"summary,omitempty"` // User's name, populated in API responses
+}
+
+// Team represents a reference to a PagerDuty team.
+type Team struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary"`
 }
 
 // Schedule represents a PagerDuty schedule.
@@ -37,18 +44,29 @@ type Schedule struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	TimeZone    string `json:"time_zone"`
+	Teams       []Team `json:"teams,omitempty"`
+}
+
+// EscalationPolicy represents a reference to a PagerDuty escalation policy.
+type EscalationPolicy struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary"`
 }
 
 // OnCall represents an on-call shift from the PagerDuty API.
+// Start and End are zero for users who are permanently on call at a level.
 type OnCall struct {
-	Start    time.Time `json:"start"`
-	End      time.Time `json:"end"`
-	User     User      `json:"user"`
-	Schedule Schedule  `json:"schedule"`
+	Start            time.Time        `json:"start"`
+	End              time.Time        `json:"end"`
+	User             User             `json:"user"`
+	Schedule         Schedule         `json:"schedule"`
+	EscalationPolicy EscalationPolicy `json:"escalation_policy"`
+	EscalationLevel  int              `json:"escalation_level"`
 }
 
 // Override represents a schedule override.
 type Override struct {
+	ID       string        `json:"id,omitempty"` // Assigned by PagerDuty when the override is created
 	Start    time.Time     `json:"start"`
 	End      time.Time     `json:"end"`
 	User     UserReference `json:"user"`
@@ -64,9 +82,28 @@ type Shift struct {
 
 // Config represents the application configuration.
 type Config struct {
-	PagerDutyToken string `yaml:"pagerduty_token"`
-	ScheduleID     string `yaml:"schedule_id,omitempty"`
-	MyUser         string `yaml:"my_user,omitempty"`
+	PagerDutyToken  string            `yaml:"pagerduty_token"`
+	ScheduleID      string            `yaml:"schedule_id,omitempty"`
+	Schedules       map[string]string `yaml:"schedules,omitempty"`        // Schedule aliases mapped to schedule IDs
+	DefaultSchedule string            `yaml:"default_schedule,omitempty"` // Alias or ID used when --schedule is not given
+	MyUser          string            `yaml:"my_user,omitempty"`
+	Templates       map[string]string `yaml:"templates,omitempty"` // Named output templates for --format template
+	TimeZone        string            `yaml:"time_zone,omitempty"` // IANA zone, "local" or "schedule" for input and display
+
+	// Alternatives to an inline pagerduty_token; at most one may be set.
+	PagerDutyTokenCommand string `yaml:"pagerduty_token_command,omitempty"` // Shell command printing the token
+	PagerDutyTokenFile    string `yaml:"pagerduty_token_file,omitempty"`    // File containing the token
+	PagerDutyTokenKeyring string `yaml:"pagerduty_token_keyring,omitempty"` // Keyring account holding the token
+
+	// Profiles are named sets of settings, typically one per PagerDuty
+	// account. A profile's settings replace the top-level ones it sets.
+	Profiles       map[string]*Config `yaml:"profiles,omitempty"`
+	DefaultProfile string             `yaml:"default_profile,omitempty"` // Profile used when none is selected
+	Profile        string             `yaml:"-"`                         // Name of the selected profile; empty for none
+
+	// Sources records where each effective setting came from, by key, such
+	// as "config file" or "$MYSHIFT_MY_USER". It is filled in when loading.
+	Sources map[string]string `yaml:"-"`
 }
 
 // Version represents the application version.